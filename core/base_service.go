@@ -1,6 +1,6 @@
 package core
 
-// (C) Copyright IBM Corp. 2019, 2024.
+// (C) Copyright IBM Corp. 2019, 2026.
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -59,6 +59,105 @@ type ServiceOptions struct {
 	// the "Content-Encoding" header will be added to the request with the
 	// value "gzip".
 	EnableGzipCompression bool
+
+	// Compression is an optional codec used to compress request bodies
+	// (see NewGzipCodec, NewDeflateCodec and NewZstdCodec).
+	// If specified, the body of each request processed by the service will be compressed
+	// by the codec unless the request already has a "Content-Encoding" header (e.g. because
+	// the RequestBuilder compressed it) or the body is smaller than the codec's minimum size.
+	Compression CompressionCodec
+
+	// EnableResponseDecompression indicates whether or not the service should
+	// request compressed response bodies and transparently decompress them.
+	// If enabled, the "Accept-Encoding" header will be added to each request
+	// (unless already present) with the value "gzip, deflate, zstd", and response
+	// bodies with one of those encodings will be decompressed before they are
+	// processed, including when the body is returned as a stream (io.ReadCloser).
+	// The DetailedResponse.Compression field will describe the original encoding
+	// and the compressed and uncompressed sizes of the response body.
+	EnableResponseDecompression bool
+
+	// ResponseCache is an optional HTTP cache for GET requests (see NewResponseCache).
+	// When a response is served from the cache, the DetailedResponse.FromCache field will be true.
+	ResponseCache *ResponseCache
+
+	// EnableIdempotencyKeys indicates whether or not a generated idempotency key should
+	// be added to each request with a non-idempotent method (POST or PATCH) that
+	// does not already carry one. The key is added with the header named by
+	// IdempotencyKeyHeader (or "Idempotency-Key" if not specified), and the same key is
+	// sent with each retry of the request so that the server can detect duplicates.
+	EnableIdempotencyKeys bool
+
+	// IdempotencyKeyHeader is the name of the header that carries a request's idempotency key.
+	// If not specified, "Idempotency-Key" is used.
+	IdempotencyKeyHeader string
+
+	// RequireIdempotencyKeyForRetries indicates whether or not automatic retries
+	// of requests with a non-idempotent method (POST or PATCH) should be restricted to
+	// requests that carry an idempotency key.
+	RequireIdempotencyKeyForRetries bool
+
+	// DisableRequestIDs indicates whether or not the service should refrain from adding a request ID
+	// to each request that does not already carry one. By default, the request ID is taken from the
+	// request's Context (see WithRequestID) or generated, and sent with the header named by
+	// RequestIDHeader (or "X-Request-ID" if not specified). The request ID and the transaction ID
+	// returned by the server are available in the DetailedResponse and in the problems
+	// returned by the service.
+	DisableRequestIDs bool
+
+	// RequestIDHeader is the name of the header that carries a request's ID (e.g. "X-Correlation-ID").
+	// If not specified, "X-Request-ID" is used.
+	RequestIDHeader string
+
+	// EnableTraceParent indicates whether or not each request should carry a W3C Trace Context
+	// "traceparent" header that identifies the span started for the request (or for each of its
+	// attempts, if automatic retries are enabled) by the Go core's Tracer (see SetTracer).
+	EnableTraceParent bool
+
+	// RetryPolicy is an optional description of the automatic retries performed by the service
+	// (see RetryPolicy). If specified, retries are enabled when the service is constructed.
+	RetryPolicy *RetryPolicy
+
+	// CircuitBreaker is an optional circuit breaker (see NewCircuitBreaker) that stops
+	// requests from being sent (or retried) to a host after repeated failures.
+	// A CircuitBreaker may be shared by multiple services, and is shared by clones of the service.
+	CircuitBreaker *CircuitBreaker
+
+	// RetryBudget is an optional token bucket (see NewRetryBudget) that caps automatic
+	// retries to a fraction of the requests processed by the service.
+	// A RetryBudget may be shared by multiple services, and is shared by clones of the service.
+	RetryBudget *RetryBudget
+
+	// RateLimiter is an optional client-side rate limiter (see NewRateLimiter) that holds
+	// back (or fails) requests that would exceed a static rate limit or the quota advertised
+	// by the service's rate limit headers.
+	// A RateLimiter may be shared by multiple services, and is shared by clones of the service.
+	RateLimiter *RateLimiter
+
+	// Hedging is an optional HedgingPolicy (see NewHedgingPolicy) that sends a duplicate
+	// of a (GET or HEAD) request if no response has been received after a delay, and uses
+	// whichever response is received first.
+	// A HedgingPolicy may be shared by multiple services, and is shared by clones of the service.
+	Hedging *HedgingPolicy
+
+	// Timeouts are the default timeouts applied to the requests sent by the service,
+	// which may be overridden for individual requests (see RequestTimeouts).
+	Timeouts RequestTimeouts
+
+	// DryRun optionally puts the service in "dry-run" mode (see DryRunOptions), in which
+	// each request is prepared but not sent. Instead, the prepared request is described
+	// by the DetailedResponse.Request field (with secrets redacted).
+	DryRun *DryRunOptions
+
+	// LogCurlCommands indicates whether or not each request should be logged (when debug logging
+	// is enabled) as an equivalent curl command (see CurlCommand), rather than as an HTTP dump.
+	// As with the HTTP dump, secrets are redacted.
+	LogCurlCommands bool
+
+	// Interceptors is an optional chain of Interceptor instances that will be
+	// invoked for each request processed by the service. See the Interceptor
+	// interface for details about the order in which they are invoked.
+	Interceptors []Interceptor
 }
 
 // BaseService implements the common functionality shared by generated services
@@ -106,6 +205,10 @@ func NewBaseService(options *ServiceOptions) (*BaseService, error) {
 	// Set a default value for the User-Agent http header.
 	service.SetUserAgent(service.buildUserAgent())
 
+	if options.RetryPolicy != nil {
+		service.SetRetryPolicy(options.RetryPolicy)
+	}
+
 	return &service, nil
 }
 
@@ -174,6 +277,44 @@ func (service *BaseService) ConfigureService(serviceName string) error {
 			}
 		}
 
+		// COMPRESSION
+		// If "COMPRESSION" is set, then we'll also try to retrieve "COMPRESSION_LEVEL" and
+		// "COMPRESSION_MIN_BYTES".  If those are not specified, we'll use 0 to trigger a default value for each.
+		if compression, ok := serviceProps[PROPNAME_SVC_COMPRESSION]; ok && compression != "" {
+			var level int = 0
+			var minBytes int64 = 0
+
+			if s, ok := serviceProps[PROPNAME_SVC_COMPRESSION_LEVEL]; ok && s != "" {
+				n, err := strconv.ParseInt(s, 10, 32)
+				if err == nil {
+					level = int(n)
+				}
+			}
+
+			if s, ok := serviceProps[PROPNAME_SVC_COMPRESSION_MIN_BYTES]; ok && s != "" {
+				n, err := strconv.ParseInt(s, 10, 64)
+				if err == nil {
+					minBytes = n
+				}
+			}
+
+			codec, err := NewCompressionCodec(compression, level, minBytes)
+			if err != nil {
+				err = RepurposeSDKProblem(err, "set-compression-fail")
+				return err
+			}
+			service.SetCompression(codec)
+		}
+
+		// ENABLE_DECOMPRESSION
+		if enableDecompression, ok := serviceProps[PROPNAME_SVC_ENABLE_DECOMPRESSION]; ok && enableDecompression != "" {
+			// Convert the config string to bool.
+			boolValue, err := strconv.ParseBool(enableDecompression)
+			if err == nil {
+				service.SetEnableResponseDecompression(boolValue)
+			}
+		}
+
 		// ENABLE_RETRIES
 		// If "ENABLE_RETRIES" is set to true, then we'll also try to retrieve "MAX_RETRIES" and
 		// "RETRY_INTERVAL".  If those are not specified, we'll use 0 to trigger a default value for each.
@@ -199,7 +340,63 @@ func (service *BaseService) ConfigureService(serviceName string) error {
 					}
 				}
 
-				service.EnableRetries(maxRetries, retryInterval)
+				// If any of the retry policy properties are specified, then we'll configure
+				// a RetryPolicy rather than just enabling retries.
+				policy, err := getRetryPolicyFromProperties(serviceProps)
+				if err != nil {
+					return err
+				}
+				if policy != nil {
+					policy.MaxRetries = maxRetries
+					policy.MaxWait = retryInterval
+					service.SetRetryPolicy(policy)
+				} else {
+					service.EnableRetries(maxRetries, retryInterval)
+				}
+			}
+		}
+
+		// ENABLE_CIRCUIT_BREAKER
+		// If "ENABLE_CIRCUIT_BREAKER" is set to true, then we'll also try to retrieve
+		// "CIRCUIT_BREAKER_FAILURE_THRESHOLD" and "CIRCUIT_BREAKER_COOL_DOWN" (in seconds).
+		if enableBreaker, ok := serviceProps[PROPNAME_SVC_ENABLE_CIRCUIT_BREAKER]; ok && enableBreaker != "" {
+			boolValue, err := strconv.ParseBool(enableBreaker)
+			if boolValue && err == nil {
+				var failureThreshold int = 0
+				var coolDown time.Duration = 0
+
+				if s, ok := serviceProps[PROPNAME_SVC_CIRCUIT_BREAKER_THRESHOLD]; ok && s != "" {
+					n, err := strconv.ParseInt(s, 10, 32)
+					if err == nil {
+						failureThreshold = int(n)
+					}
+				}
+
+				if s, ok := serviceProps[PROPNAME_SVC_CIRCUIT_BREAKER_COOL_DOWN]; ok && s != "" {
+					n, err := strconv.ParseInt(s, 10, 32)
+					if err == nil {
+						coolDown = time.Duration(n) * time.Second
+					}
+				}
+
+				service.SetCircuitBreaker(NewCircuitBreaker(failureThreshold, coolDown))
+			}
+		}
+
+		// RETRY_BUDGET_RATIO
+		// If "RETRY_BUDGET_RATIO" is set, then we'll also try to retrieve "RETRY_BUDGET_MAX_TOKENS".
+		if s, ok := serviceProps[PROPNAME_SVC_RETRY_BUDGET_RATIO]; ok && s != "" {
+			ratio, err := strconv.ParseFloat(s, 64)
+			if ratio > 0 && err == nil {
+				var maxTokens int = 0
+				if s, ok := serviceProps[PROPNAME_SVC_RETRY_BUDGET_MAX_TOKENS]; ok && s != "" {
+					n, err := strconv.ParseInt(s, 10, 32)
+					if err == nil {
+						maxTokens = int(n)
+					}
+				}
+
+				service.SetRetryBudget(NewRetryBudget(ratio, maxTokens))
 			}
 		}
 	}
@@ -328,6 +525,210 @@ func (service *BaseService) GetEnableGzipCompression() bool {
 	return service.Options.EnableGzipCompression
 }
 
+// SetCompression sets the service's Compression field
+func (service *BaseService) SetCompression(codec CompressionCodec) {
+	service.Options.Compression = codec
+}
+
+// GetCompression returns the service's Compression field
+func (service *BaseService) GetCompression() CompressionCodec {
+	return service.Options.Compression
+}
+
+// SetEnableResponseDecompression sets the service's EnableResponseDecompression field
+func (service *BaseService) SetEnableResponseDecompression(enableDecompression bool) {
+	service.Options.EnableResponseDecompression = enableDecompression
+}
+
+// GetEnableResponseDecompression returns the service's EnableResponseDecompression field
+func (service *BaseService) GetEnableResponseDecompression() bool {
+	return service.Options.EnableResponseDecompression
+}
+
+// SetResponseCache sets the service's ResponseCache field
+func (service *BaseService) SetResponseCache(cache *ResponseCache) {
+	service.Options.ResponseCache = cache
+}
+
+// GetResponseCache returns the service's ResponseCache field
+func (service *BaseService) GetResponseCache() *ResponseCache {
+	return service.Options.ResponseCache
+}
+
+// SetEnableIdempotencyKeys sets the service's EnableIdempotencyKeys field
+func (service *BaseService) SetEnableIdempotencyKeys(enableIdempotencyKeys bool) {
+	service.Options.EnableIdempotencyKeys = enableIdempotencyKeys
+}
+
+// GetEnableIdempotencyKeys returns the service's EnableIdempotencyKeys field
+func (service *BaseService) GetEnableIdempotencyKeys() bool {
+	return service.Options.EnableIdempotencyKeys
+}
+
+// SetIdempotencyKeyHeader sets the service's IdempotencyKeyHeader field
+func (service *BaseService) SetIdempotencyKeyHeader(headerName string) {
+	service.Options.IdempotencyKeyHeader = headerName
+}
+
+// GetIdempotencyKeyHeader returns the service's IdempotencyKeyHeader field
+func (service *BaseService) GetIdempotencyKeyHeader() string {
+	return service.Options.IdempotencyKeyHeader
+}
+
+// SetDisableRequestIDs sets the service's DisableRequestIDs field
+func (service *BaseService) SetDisableRequestIDs(disableRequestIDs bool) {
+	service.Options.DisableRequestIDs = disableRequestIDs
+}
+
+// GetDisableRequestIDs returns the service's DisableRequestIDs field
+func (service *BaseService) GetDisableRequestIDs() bool {
+	return service.Options.DisableRequestIDs
+}
+
+// SetRequestIDHeader sets the service's RequestIDHeader field
+func (service *BaseService) SetRequestIDHeader(headerName string) {
+	service.Options.RequestIDHeader = headerName
+}
+
+// GetRequestIDHeader returns the service's RequestIDHeader field
+func (service *BaseService) GetRequestIDHeader() string {
+	return service.Options.RequestIDHeader
+}
+
+// SetEnableTraceParent sets the service's EnableTraceParent field
+func (service *BaseService) SetEnableTraceParent(enableTraceParent bool) {
+	service.Options.EnableTraceParent = enableTraceParent
+}
+
+// GetEnableTraceParent returns the service's EnableTraceParent field
+func (service *BaseService) GetEnableTraceParent() bool {
+	return service.Options.EnableTraceParent
+}
+
+// SetRequireIdempotencyKeyForRetries sets the service's RequireIdempotencyKeyForRetries field
+func (service *BaseService) SetRequireIdempotencyKeyForRetries(requireIdempotencyKey bool) {
+	service.Options.RequireIdempotencyKeyForRetries = requireIdempotencyKey
+}
+
+// GetRequireIdempotencyKeyForRetries returns the service's RequireIdempotencyKeyForRetries field
+func (service *BaseService) GetRequireIdempotencyKeyForRetries() bool {
+	return service.Options.RequireIdempotencyKeyForRetries
+}
+
+// SetRetryPolicy sets the service's retry policy and enables automatic retries with the
+// policy's MaxRetries and MaxWait values. If "policy" is nil, then the service reverts
+// to the default retry policy (retries remain enabled, if they were enabled before).
+func (service *BaseService) SetRetryPolicy(policy *RetryPolicy) {
+	service.Options.RetryPolicy = policy
+	if policy != nil {
+		service.EnableRetries(policy.MaxRetries, policy.MaxWait)
+	} else if isRetryableClient(service.Client) {
+		service.applyRetryPolicy(service.Client.Transport.(*retryablehttp.RoundTripper).Client)
+	}
+}
+
+// GetRetryPolicy returns the service's retry policy.
+func (service *BaseService) GetRetryPolicy() *RetryPolicy {
+	return service.Options.RetryPolicy
+}
+
+// applyRetryPolicy configures the min wait and backoff function of "client"
+// according to the service's retry policy.
+func (service *BaseService) applyRetryPolicy(client *retryablehttp.Client) {
+	policy := service.Options.RetryPolicy
+	if policy == nil {
+		client.RetryWaitMin = defaultRetryWaitMin
+		client.Backoff = IBMCloudSDKBackoffPolicy
+		return
+	}
+	if policy.MinWait > 0 {
+		client.RetryWaitMin = policy.MinWait
+	}
+	client.Backoff = policy.Backoff
+}
+
+// SetCircuitBreaker sets the service's circuit breaker (nil to disable it).
+func (service *BaseService) SetCircuitBreaker(breaker *CircuitBreaker) {
+	service.Options.CircuitBreaker = breaker
+}
+
+// GetCircuitBreaker returns the service's circuit breaker.
+func (service *BaseService) GetCircuitBreaker() *CircuitBreaker {
+	return service.Options.CircuitBreaker
+}
+
+// SetRetryBudget sets the service's retry budget (nil to disable it).
+func (service *BaseService) SetRetryBudget(budget *RetryBudget) {
+	service.Options.RetryBudget = budget
+}
+
+// GetRetryBudget returns the service's retry budget.
+func (service *BaseService) GetRetryBudget() *RetryBudget {
+	return service.Options.RetryBudget
+}
+
+// SetRateLimiter sets the service's rate limiter (nil to disable it).
+func (service *BaseService) SetRateLimiter(limiter *RateLimiter) {
+	service.Options.RateLimiter = limiter
+}
+
+// GetRateLimiter returns the service's rate limiter.
+func (service *BaseService) GetRateLimiter() *RateLimiter {
+	return service.Options.RateLimiter
+}
+
+// SetHedging sets the service's hedging policy (nil to disable hedging).
+func (service *BaseService) SetHedging(policy *HedgingPolicy) {
+	service.Options.Hedging = policy
+}
+
+// GetHedging returns the service's hedging policy.
+func (service *BaseService) GetHedging() *HedgingPolicy {
+	return service.Options.Hedging
+}
+
+// SetTimeouts sets the default timeouts applied to the requests sent by the service.
+func (service *BaseService) SetTimeouts(timeouts RequestTimeouts) {
+	service.Options.Timeouts = timeouts
+}
+
+// GetTimeouts returns the default timeouts applied to the requests sent by the service.
+func (service *BaseService) GetTimeouts() RequestTimeouts {
+	return service.Options.Timeouts
+}
+
+// SetDryRun sets the service's DryRun field (nil to disable dry-run mode)
+func (service *BaseService) SetDryRun(options *DryRunOptions) {
+	service.Options.DryRun = options
+}
+
+// GetDryRun returns the service's DryRun field
+func (service *BaseService) GetDryRun() *DryRunOptions {
+	return service.Options.DryRun
+}
+
+// SetLogCurlCommands sets the service's LogCurlCommands field
+func (service *BaseService) SetLogCurlCommands(logCurlCommands bool) {
+	service.Options.LogCurlCommands = logCurlCommands
+}
+
+// GetLogCurlCommands returns the service's LogCurlCommands field
+func (service *BaseService) GetLogCurlCommands() bool {
+	return service.Options.LogCurlCommands
+}
+
+// AddInterceptor adds "interceptor" to the end of the service's chain of interceptors.
+func (service *BaseService) AddInterceptor(interceptor Interceptor) {
+	// Make sure we don't modify a chain that might be shared with a clone of this service.
+	chain := service.Options.Interceptors
+	service.Options.Interceptors = append(chain[:len(chain):len(chain)], interceptor)
+}
+
+// GetInterceptors returns the service's chain of interceptors.
+func (service *BaseService) GetInterceptors() []Interceptor {
+	return service.Options.Interceptors
+}
+
 // buildUserAgent builds the user agent string.
 func (service *BaseService) buildUserAgent() string {
 	return fmt.Sprintf("%s-%s %s", sdkName, __VERSION__, SystemInfo())
@@ -357,6 +758,135 @@ func (service *BaseService) SetUserAgent(userAgent string) {
 //
 // err: a non-nil error object if an error occurred
 func (service *BaseService) Request(req *http.Request, result interface{}) (detailedResponse *DetailedResponse, err error) {
+	detailedResponse, err = service.invoke(req, func(httpResponse *http.Response) (*DetailedResponse, error) {
+		return processSuccessResponse(httpResponse, result)
+	})
+	err = RepurposeSDKProblem(err, "")
+	return
+}
+
+// invoke runs the request processing pipeline shared by Request() and its variants:
+// the request is prepared and sent (with any interceptors applied), and then a successful
+// response is handed to "processResponse" while an error response is processed by
+// processErrorResponse().
+func (service *BaseService) invoke(req *http.Request,
+	processResponse func(*http.Response) (*DetailedResponse, error)) (detailedResponse *DetailedResponse, err error) {
+	// Trace the request and record its metrics once its outcome is known.
+	start := time.Now()
+	req, span := startRequestSpan(req, SPAN_NAME_REQUEST)
+	defer func() {
+		retries := 0
+		if detailedResponse != nil && len(detailedResponse.Attempts) > 1 {
+			retries = len(detailedResponse.Attempts) - 1
+		}
+		if service.Options.DryRun == nil {
+			GetMetrics().ObserveRequest(getRequestMetricLabels(req, detailedResponse), time.Since(start), retries)
+		}
+
+		if detailedResponse != nil {
+			span.SetAttribute(SPAN_ATTR_HTTP_STATUS_CODE, detailedResponse.StatusCode)
+			if detailedResponse.TransactionID != "" {
+				span.SetAttribute(SPAN_ATTR_TRANSACTION_ID, detailedResponse.TransactionID)
+			}
+			if retries > 0 {
+				span.SetAttribute(SPAN_ATTR_HTTP_RESEND, retries)
+			}
+		}
+		endSpan(span, err)
+	}()
+
+	detailedResponse, err = service.prepareRequest(req)
+	if err != nil {
+		return
+	}
+	if requestID := service.requestID(req); requestID != "" {
+		span.SetAttribute(SPAN_ATTR_REQUEST_ID, requestID)
+	}
+	if service.Options.EnableTraceParent {
+		injectTraceParent(req, span)
+	}
+
+	// Give each interceptor the opportunity to see the request before it is sent.
+	// Only those interceptors that were invoked successfully will see the final outcome.
+	var compression *ResponseCompression
+	var fromCache bool
+	state := &requestState{}
+	chain := service.Options.Interceptors
+	n, err := interceptRequest(chain, req)
+	defer func() {
+		if detailedResponse != nil {
+			detailedResponse.Compression = compression
+			detailedResponse.FromCache = fromCache
+			detailedResponse.Attempts = state.history
+			detailedResponse.Hedges = state.hedges
+			detailedResponse.RequestID = service.requestID(req)
+			detailedResponse.TransactionID = detailedResponse.Headers.Get(TRANSACTION_ID_HEADER)
+		}
+		var sdkErr *SDKProblem
+		if errors.As(err, &sdkErr) {
+			if len(state.history) > 0 {
+				sdkErr.attempts = state.history
+			}
+			sdkErr.requestID = service.requestID(req)
+			if detailedResponse != nil {
+				sdkErr.transactionID = detailedResponse.TransactionID
+			}
+		}
+		err = interceptDetailedResponse(chain[:n], req, detailedResponse, err)
+	}()
+	if err != nil {
+		return
+	}
+
+	// In dry-run mode, the prepared request is captured instead of being sent.
+	if service.Options.DryRun != nil {
+		var captured *CapturedRequest
+		captured, err = captureRequest(req)
+		if err == nil {
+			detailedResponse = &DetailedResponse{Request: captured}
+		}
+		return
+	}
+
+	// The request's state will be populated as the request is sent (e.g. with its retry attempts).
+	httpResponse, fromCache, err := service.sendCachedRequest(req.WithContext(withRequestState(req.Context(), state)))
+	if err != nil {
+		return
+	}
+
+	if service.Options.EnableResponseDecompression {
+		compression, err = decompressResponse(httpResponse)
+		if err != nil {
+			_ = httpResponse.Body.Close()
+			detailedResponse, _ = getDetailedResponseAndContentType(httpResponse)
+			return
+		}
+	}
+
+	err = interceptResponse(chain, req, httpResponse)
+	if err != nil {
+		if !IsNil(httpResponse.Body) {
+			_ = httpResponse.Body.Close()
+		}
+		detailedResponse, _ = getDetailedResponseAndContentType(httpResponse)
+		return
+	}
+
+	// If the operation was unsuccessful, then set up and return
+	// the DetailedResponse and error objects appropriately.
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode >= 300 {
+		detailedResponse, err = processErrorResponse(httpResponse)
+		err = RepurposeSDKProblem(err, "error-response")
+		return
+	}
+
+	return processResponse(httpResponse)
+}
+
+// prepareRequest adds the default headers, User-Agent header and authentication
+// information to "req". If the authentication step fails with an error response,
+// then the returned DetailedResponse will describe that response.
+func (service *BaseService) prepareRequest(req *http.Request) (detailedResponse *DetailedResponse, err error) {
 	// Set default headers on the request.
 	if service.DefaultHeaders != nil {
 		for k, v := range service.DefaultHeaders {
@@ -379,7 +909,31 @@ func (service *BaseService) Request(req *http.Request, result interface{}) (deta
 		req.Header.Add(headerNameUserAgent, service.UserAgent)
 	}
 
+	// Compress the request body if a codec is configured and the body isn't already compressed.
+	if !IsNil(service.Options.Compression) && !IsNil(req.Body) && req.Body != http.NoBody &&
+		req.Header.Get(CONTENT_ENCODING) == "" {
+		err = compressRequest(req, service.Options.Compression)
+		if err != nil {
+			err = RepurposeSDKProblem(err, "compress-request-error")
+			return
+		}
+	}
+
+	// Add an idempotency key, if needed.
+	service.addIdempotencyKey(req)
+
+	// Add a request ID, if needed.
+	service.addRequestID(req)
+
+	// Ask for a compressed response body if we're able to decompress it.
+	if service.Options.EnableResponseDecompression && req.Header.Get(headerNameAcceptEncoding) == "" {
+		req.Header.Set(headerNameAcceptEncoding, acceptEncodingValue)
+	}
+
 	// Add authentication to the outbound request.
+	if service.authenticateDryRun(req) {
+		return
+	}
 	if IsNil(service.Options.Authenticator) {
 		err = errors.New(ERRORMSG_NO_AUTHENTICATOR)
 		err = SDKErrorf(err, "", "missing-auth", getComponentInfo())
@@ -406,8 +960,41 @@ func (service *BaseService) Request(req *http.Request, result interface{}) (deta
 		return
 	}
 
-	// If debug is enabled, then dump the request.
-	if GetLogger().IsLogLevelEnabled(LevelDebug) {
+	return
+}
+
+// sendCachedRequest sends "req" by way of the service's ResponseCache (if any), so that
+// GET requests may be served from the cache and other requests may invalidate cache entries.
+func (service *BaseService) sendCachedRequest(req *http.Request) (httpResponse *http.Response, fromCache bool, err error) {
+	cache := service.Options.ResponseCache
+	if cache == nil {
+		httpResponse, err = service.sendRequest(req)
+		return
+	}
+
+	if req.Method == http.MethodGet {
+		return cache.send(req, service.sendRequest)
+	}
+
+	httpResponse, err = service.sendRequest(req)
+	if err == nil && req.Method != http.MethodHead && req.Method != http.MethodOptions &&
+		httpResponse.StatusCode >= 200 && httpResponse.StatusCode < 400 {
+		cache.invalidate(req)
+	}
+	return
+}
+
+// sendRequest sends "req" using the service's http.Client and returns the response.
+func (service *BaseService) sendRequest(req *http.Request) (httpResponse *http.Response, err error) {
+	// If debug is enabled, then dump the request (or log it as a curl command).
+	if GetLogger().IsLogLevelEnabled(LevelDebug) && service.Options.LogCurlCommands {
+		command, curlErr := CurlCommand(req, &CurlOptions{Insecure: service.IsSSLDisabled()})
+		if curlErr == nil {
+			GetLogger().Debug("Request:\n%s\n", command)
+		} else {
+			GetLogger().Debug("error while attempting to log outbound request: %s", curlErr.Error())
+		}
+	} else if GetLogger().IsLogLevelEnabled(LevelDebug) {
 		buf, dumpErr := httputil.DumpRequestOut(req, !IsNil(req.Body))
 		if dumpErr == nil {
 			GetLogger().Debug("Request:\n%s\n", RedactSecrets(string(buf)))
@@ -416,11 +1003,91 @@ func (service *BaseService) Request(req *http.Request, result interface{}) (deta
 		}
 	}
 
+	// Apply the request's timeouts. The total timeout must remain in effect until
+	// the response body has been read.
+	timeouts := service.requestTimeouts(req)
+	if timeouts.Total > 0 {
+		var cancel context.CancelFunc
+		req, cancel = withTotalTimeout(req, timeouts.Total)
+		defer func() {
+			if err == nil {
+				httpResponse.Body = &cancelOnCloseBody{ReadCloser: httpResponse.Body, cancel: cancel}
+			} else {
+				cancel()
+			}
+		}()
+	}
+	client := recordingClient(service.Client, "")
+	if timeouts.Attempt > 0 || timeouts.FirstByte > 0 {
+		client = clientWithAttemptTimeouts(client, timeouts)
+	}
+
+	// Make the request's state available to the retry policy.
+	state := getRequestState(req.Context())
+	if state == nil {
+		state = &requestState{}
+		req = req.WithContext(withRequestState(req.Context(), state))
+	}
+	*state = requestState{
+		noRetry:        service.isRetryForbidden(req),
+		method:         req.Method,
+		host:           req.URL.Host,
+		start:          time.Now(),
+		retryPolicy:    service.Options.RetryPolicy,
+		circuitBreaker: service.Options.CircuitBreaker,
+		retryBudget:    service.Options.RetryBudget,
+		rateLimiter:    service.Options.RateLimiter,
+		traceParent:    service.Options.EnableTraceParent,
+	}
+	if isRetryableClient(service.Client) {
+		state.maxRetries = service.Client.Transport.(*retryablehttp.RoundTripper).Client.RetryMax
+	}
+
+	// Fail fast if the circuit for the request's host is open.
+	if state.circuitBreaker != nil && !state.circuitBreaker.allow(state.host) {
+		err = fmt.Errorf(ERRORMSG_CIRCUIT_OPEN, state.host)
+		err = SDKErrorf(err, "", "circuit-open", getComponentInfo())
+		return
+	}
+	if state.retryBudget != nil {
+		state.retryBudget.deposit()
+	}
+
+	// Wait until the rate limiter allows the request to be sent.
+	if state.rateLimiter != nil {
+		err = state.rateLimiter.wait(req.Context(), false)
+		if err != nil {
+			// The request won't be sent, so give up its trial slot (if any).
+			if state.circuitBreaker != nil {
+				state.circuitBreaker.release(state.host)
+			}
+			return
+		}
+	}
+
 	// Invoke the request, then check for errors during the invocation.
 	GetLogger().Debug("Sending HTTP request message...")
-	var httpResponse *http.Response
-	httpResponse, err = service.Client.Do(req)
+	if policy := service.hedgingPolicy(req); policy != nil {
+		httpResponse, err = sendHedgedRequest(client, req, state, policy)
+	} else {
+		httpResponse, err = state.retryClient(client).Do(req)
+	}
+
+	// If the retry policy didn't see the outcome, then record it here.
+	if state.attempts == 0 {
+		if state.circuitBreaker != nil {
+			state.circuitBreaker.record(state.host, httpResponse, attemptError(req.Context(), err))
+		}
+		if state.rateLimiter != nil {
+			state.rateLimiter.update(httpResponse)
+		}
+	}
 	if err != nil {
+		var timeoutErr *timeoutError
+		if errors.As(context.Cause(req.Context()), &timeoutErr) || errors.As(err, &timeoutErr) {
+			err = SDKErrorf(err, timeoutErr.Error(), timeoutErr.discriminator, getComponentInfo())
+			return
+		}
 		if strings.Contains(err.Error(), SSL_CERTIFICATION_ERROR) {
 			err = errors.New(ERRORMSG_SSL_VERIFICATION_FAILED + "\n" + err.Error())
 		}
@@ -439,14 +1106,12 @@ func (service *BaseService) Request(req *http.Request, result interface{}) (deta
 		}
 	}
 
-	// If the operation was unsuccessful, then set up and return
-	// the DetailedResponse and error objects appropriately.
-	if httpResponse.StatusCode < 200 || httpResponse.StatusCode >= 300 {
-		detailedResponse, err = processErrorResponse(httpResponse)
-		err = RepurposeSDKProblem(err, "error-response")
-		return
-	}
+	return
+}
 
+// processSuccessResponse processes a successful response, storing the response body
+// in "result" according to its type.
+func processSuccessResponse(httpResponse *http.Response, result interface{}) (detailedResponse *DetailedResponse, err error) {
 	// Operation was successful and we are expecting a response, so process the response.
 	detailedResponse, contentType := getDetailedResponseAndContentType(httpResponse)
 	if !IsNil(result) {
@@ -708,6 +1373,55 @@ func isRetryableClient(client *http.Client) bool {
 	return isRetryable
 }
 
+// clientWithTransport returns a copy of "client" whose transport is wrapped by "wrap".
+// If "client" is a retryable client, then the transport of its embedded client is wrapped,
+// so that the wrapping transport sees each attempt to send a request.
+func clientWithTransport(client *http.Client, wrap func(http.RoundTripper) http.RoundTripper) *http.Client {
+	wrapClient := func(c *http.Client) *http.Client {
+		if c == nil {
+			c = DefaultHTTPClient()
+		}
+		clone := *c
+		transport := clone.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+		clone.Transport = wrap(transport)
+		return &clone
+	}
+
+	if !isRetryableClient(client) {
+		return wrapClient(client)
+	}
+	return cloneRetryableClient(client, func(rc *retryablehttp.Client) {
+		rc.HTTPClient = wrapClient(rc.HTTPClient)
+	})
+}
+
+// cloneRetryableClient returns a copy of the retryable "client" whose embedded
+// retryablehttp.Client is a copy modified by "update".
+func cloneRetryableClient(client *http.Client, update func(*retryablehttp.Client)) *http.Client {
+	// The retryable client contains locks, so its configuration must be copied field by field.
+	rc := client.Transport.(*retryablehttp.RoundTripper).Client
+	rcClone := &retryablehttp.Client{
+		HTTPClient:      rc.HTTPClient,
+		Logger:          rc.Logger,
+		RetryWaitMin:    rc.RetryWaitMin,
+		RetryWaitMax:    rc.RetryWaitMax,
+		RetryMax:        rc.RetryMax,
+		RequestLogHook:  rc.RequestLogHook,
+		ResponseLogHook: rc.ResponseLogHook,
+		CheckRetry:      rc.CheckRetry,
+		Backoff:         rc.Backoff,
+		ErrorHandler:    rc.ErrorHandler,
+		PrepareRetry:    rc.PrepareRetry,
+	}
+	update(rcClone)
+	clone := *client
+	clone.Transport = &retryablehttp.RoundTripper{Client: rcClone}
+	return &clone
+}
+
 // EnableRetries will configure the service to perform automatic retries of failed requests.
 // If "maxRetries" and/or "maxRetryInterval" are specified as 0, then default values
 // are used instead.
@@ -746,6 +1460,9 @@ func (service *BaseService) EnableRetries(maxRetries int, maxRetryInterval time.
 		if maxRetryInterval > 0 {
 			tr.Client.RetryWaitMax = maxRetryInterval
 		}
+		if service.Options.RetryPolicy != nil {
+			service.applyRetryPolicy(tr.Client)
+		}
 	} else {
 		// Otherwise, we need to create a new retryable client instance
 		// and hang it off the base service.
@@ -756,6 +1473,9 @@ func (service *BaseService) EnableRetries(maxRetries int, maxRetryInterval time.
 		if maxRetryInterval > 0 {
 			client.RetryWaitMax = maxRetryInterval
 		}
+		if service.Options.RetryPolicy != nil {
+			service.applyRetryPolicy(client)
+		}
 
 		// Hang the retryable client off the base service via the "shim" client.
 		service.Client = client.StandardClient()
@@ -811,6 +1531,7 @@ func NewRetryableClientWithHTTPClient(httpClient *http.Client) *retryablehttp.Cl
 	client.CheckRetry = IBMCloudSDKRetryPolicy
 	client.Backoff = IBMCloudSDKBackoffPolicy
 	client.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	client.RequestLogHook = retryAttemptHook
 
 	if httpClient != nil {
 		// If a non-nil http client was passed in, then let's use that
@@ -849,7 +1570,32 @@ var (
 // IBMCloudSDKRetryPolicy provides a default implementation of the CheckRetry interface
 // associated with a retryablehttp.Client.
 // This function will return true if the specified request/response should be retried.
+// If the BaseService that sent the request has a RetryPolicy, then the policy makes the decision.
+// A request with a non-idempotent method is not retried if it lacks an idempotency key
+// and the BaseService that sent it requires one (see ServiceOptions.RequireIdempotencyKeyForRetries).
+// If the BaseService has a CircuitBreaker and/or RetryBudget, then the outcome of each
+// attempt is recorded by the circuit breaker, and a request is not retried if the circuit
+// for its host is open or the retry budget is exhausted. If the BaseService has a RateLimiter,
+// then each retry waits until the rate limiter allows it to be sent.
 func IBMCloudSDKRetryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	state := getRequestState(ctx)
+
+	var retry bool
+	var retryErr error
+	if state != nil && state.retryPolicy != nil {
+		retry, retryErr = state.retryPolicy.checkRetry(ctx, state.method, state.start, resp, err)
+	} else {
+		retry, retryErr = checkRetry(ctx, resp, err)
+	}
+
+	if state != nil && !state.checkAttempt(ctx, resp, err, retry) {
+		return false, nil
+	}
+	return retry, retryErr
+}
+
+// checkRetry returns true if the specified request/response should be retried.
+func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
 	// This logic was adapted from go-retryablehttp.ErrorPropagatedRetryPolicy().
 
 	if GetLogger().IsLogLevelEnabled(LevelDebug) {
@@ -932,24 +1678,8 @@ func IBMCloudSDKRetryPolicy(ctx context.Context, resp *http.Response, err error)
 // This function will return the wait time to be associated with the next retry attempt.
 func IBMCloudSDKBackoffPolicy(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
 	// Check for a Retry-After header.
-	if resp != nil {
-		if s, ok := resp.Header["Retry-After"]; ok {
-			GetLogger().Debug("Found Retry-After header: %s\n", s)
-
-			// First, try to parse the value as an integer (number of seconds to wait)
-			if sleep, err := strconv.ParseInt(s[0], 10, 64); err == nil {
-				return time.Second * time.Duration(sleep)
-			}
-
-			// Otherwise, try to parse the value as an HTTP Time value.
-			if retryTime, err := http.ParseTime(s[0]); err == nil {
-				sleep := time.Until(retryTime)
-				if sleep > max {
-					sleep = max
-				}
-				return sleep
-			}
-		}
+	if wait, ok := retryAfterWait(resp, max); ok {
+		return wait
 	}
 
 	// If no header-based wait time can be determined, then ask DefaultBackoff()
@@ -0,0 +1,399 @@
+//go:build all || fast || basesvc
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// cassetteTestService returns a service (with an IAM authenticator) that sends its requests,
+// including its token requests, by way of "cassette".
+func cassetteTestService(t *testing.T, url string, cassette *Cassette) *BaseService {
+	authenticator, err := NewIamAuthenticatorBuilder().
+		SetApiKey("my-apikey").
+		SetURL(url).
+		SetClient(cassette.Client()).
+		Build()
+	assert.Nil(t, err)
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           url,
+		Authenticator: authenticator,
+	})
+	assert.Nil(t, err)
+	service.SetHTTPClient(cassette.Client())
+	return service
+}
+
+// cassetteTestRequest sends a request for the instance named "name" and returns its result.
+func cassetteTestRequest(service *BaseService, name string) (result map[string]interface{}, err error) {
+	builder := NewRequestBuilder(POST)
+	_, _ = builder.ResolveRequestURL(service.GetServiceURL(), "/instances", nil)
+	builder.AddQuery("limit", "10")
+	builder.AddQuery("apikey", "my-apikey")
+	builder.AddHeader(CONTENT_TYPE, "application/json")
+	builder.AddHeader("X-Region", "us-south")
+	_, _ = builder.SetBodyContentJSON(map[string]interface{}{"name": name, "password": "my-password"})
+	req, _ := builder.Build()
+	_, err = service.Request(req, &result)
+	return
+}
+
+// recordCassette records requests for instances "a" and "b" in a cassette saved as "filename".
+func recordCassette(t *testing.T, filename string) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/identity/token" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"access_token": "%s", "refresh_token": "refresh", "token_type": "Bearer", "expires_in": 60, "expiration": %d}`,
+				"real-token", GetCurrentTime()+60)
+			return
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set(CONTENT_TYPE, "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"name": "%s", "region": "%s"}`, body["name"], r.Header.Get("X-Region"))
+	}))
+	defer server.Close()
+
+	cassette, err := NewCassette(filename, nil)
+	assert.Nil(t, err)
+	service := cassetteTestService(t, server.URL, cassette)
+	for _, name := range []string{"a", "b"} {
+		result, err := cassetteTestRequest(service, name)
+		assert.Nil(t, err)
+		assert.Equal(t, name, result["name"])
+	}
+	assert.Len(t, cassette.Interactions(), 3)
+	assert.Nil(t, cassette.Save())
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	for _, name := range []string{"cassette.yaml", "cassette.json"} {
+		filename := filepath.Join(t.TempDir(), name)
+		recordCassette(t, filename)
+
+		// Secrets are redacted and the token is replaced with a stub.
+		data, err := os.ReadFile(filename)
+		assert.Nil(t, err)
+		assert.NotContains(t, string(data), "my-apikey")
+		assert.NotContains(t, string(data), "my-password")
+		assert.NotContains(t, string(data), "real-token")
+		assert.Contains(t, string(data), "apikey=[redacted]")
+		assert.Contains(t, string(data), "4102444800")
+
+		cassette, err := NewCassette(filename, &CassetteOptions{Mode: CassetteModeReplay})
+		assert.Nil(t, err)
+		interactions := cassette.Interactions()
+		assert.Len(t, interactions, 3)
+		assert.Equal(t, POST, interactions[0].Request.Method)
+		assert.True(t, strings.HasSuffix(interactions[0].Request.URL, "/identity/token"))
+		assert.Equal(t, []string{"[redacted]"}, interactions[1].Request.Headers["Authorization"])
+		assert.Equal(t, `{"name":"a","password":"[redacted]"}`+"\n", interactions[1].Request.Body)
+		assert.Equal(t, http.StatusCreated, interactions[1].Response.StatusCode)
+		assert.Equal(t, 3, cassette.Remaining())
+
+		// The requests are replayed without the server (whose URL is still recorded).
+		url := strings.TrimSuffix(interactions[0].Request.URL, "/identity/token")
+		service := cassetteTestService(t, url, cassette)
+		for _, name := range []string{"b", "a"} {
+			result, err := cassetteTestRequest(service, name)
+			assert.Nil(t, err)
+			assert.Equal(t, map[string]interface{}{"name": name, "region": "us-south"}, result)
+		}
+		assert.Zero(t, cassette.Remaining())
+
+		// The stubbed token doesn't expire.
+		token, err := service.Options.Authenticator.(*IamAuthenticator).GetToken()
+		assert.Nil(t, err)
+		claims, err := parseJWT(token)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(cassetteTokenExpiration), claims.ExpiresAt)
+
+		// In lenient mode, requests may be repeated.
+		_, err = cassetteTestRequest(service, "a")
+		assert.Nil(t, err)
+
+		// Requests that weren't recorded fail.
+		_, err = cassetteTestRequest(service, "c")
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "No interaction recorded in the cassette matches the request: POST "+url+"/instances?")
+	}
+}
+
+func TestCassetteStrict(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	filename := filepath.Join(t.TempDir(), "cassette.yml")
+	recordCassette(t, filename)
+
+	replay := func(names ...string) (*Cassette, error) {
+		cassette, err := NewCassette(filename, &CassetteOptions{Mode: CassetteModeReplay, Strict: true})
+		assert.Nil(t, err)
+		url := strings.TrimSuffix(cassette.Interactions()[0].Request.URL, "/identity/token")
+		service := cassetteTestService(t, url, cassette)
+		for _, name := range names {
+			if _, err = cassetteTestRequest(service, name); err != nil {
+				return cassette, err
+			}
+		}
+		return cassette, nil
+	}
+
+	cassette, err := replay("a", "b")
+	assert.Nil(t, err)
+	assert.Zero(t, cassette.Remaining())
+
+	// The requests must be sent in the order in which they were recorded...
+	cassette, err = replay("b")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "does not match the next interaction recorded in the cassette")
+	assert.Equal(t, 2, cassette.Remaining())
+
+	// ... and can't be repeated.
+	_, err = replay("a", "b", "a")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "All of the interactions recorded in the cassette have been replayed")
+}
+
+func TestCassetteMatching(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	filename := filepath.Join(t.TempDir(), "cassette.json")
+	recordCassette(t, filename)
+
+	send := func(options *CassetteOptions, modify func(req *http.Request)) error {
+		options.Mode = CassetteModeReplay
+		cassette, err := NewCassette(filename, options)
+		assert.Nil(t, err)
+		url := strings.TrimSuffix(cassette.Interactions()[1].Request.URL, "/instances?apikey=[redacted]&limit=10")
+
+		builder := NewRequestBuilder(POST)
+		_, _ = builder.ResolveRequestURL(url, "/instances", nil)
+		builder.AddQuery("limit", "10")
+		builder.AddQuery("apikey", "another-apikey")
+		builder.AddHeader("X-Region", "us-south")
+		_, _ = builder.SetBodyContentJSON(map[string]interface{}{"name": "a", "password": "another-password"})
+		req, _ := builder.Build()
+		modify(req)
+		resp, err := cassette.RoundTrip(req)
+		if err == nil {
+			assert.Equal(t, http.StatusCreated, resp.StatusCode)
+			assert.Equal(t, "201 Created", resp.Status)
+			assert.Equal(t, "application/json", resp.Header.Get(CONTENT_TYPE))
+		}
+		return err
+	}
+
+	// Secrets and the order of query parameters don't matter.
+	assert.Nil(t, send(&CassetteOptions{}, func(req *http.Request) {
+		req.URL.RawQuery = "limit=10&apikey=secret"
+	}))
+	assert.NotNil(t, send(&CassetteOptions{}, func(req *http.Request) {
+		req.URL.RawQuery = "limit=20"
+	}))
+	assert.NotNil(t, send(&CassetteOptions{}, func(req *http.Request) {
+		req.Method = PUT
+	}))
+
+	// Headers are only matched if requested.
+	modifyHeader := func(req *http.Request) {
+		req.Header.Set("X-Region", "eu-de")
+	}
+	assert.Nil(t, send(&CassetteOptions{}, modifyHeader))
+	assert.NotNil(t, send(&CassetteOptions{MatchHeaders: []string{"x-region"}}, modifyHeader))
+	assert.Nil(t, send(&CassetteOptions{MatchHeaders: []string{"x-region"}}, func(req *http.Request) {}))
+
+	// Bodies may be ignored.
+	modifyBody := func(req *http.Request) {
+		req.Body = io.NopCloser(strings.NewReader(`{"name":"c"}`))
+		req.GetBody = nil
+	}
+	assert.NotNil(t, send(&CassetteOptions{}, modifyBody))
+	assert.Nil(t, send(&CassetteOptions{IgnoreBody: true}, modifyBody))
+
+	// Matching may be customized.
+	matcher := func(recorded *CassetteRequest, req *CassetteRequest) bool {
+		return strings.HasSuffix(recorded.URL, "limit=10") && req.Method == PUT
+	}
+	assert.Nil(t, send(&CassetteOptions{Matcher: matcher}, func(req *http.Request) {
+		req.Method = PUT
+	}))
+	assert.NotNil(t, send(&CassetteOptions{Matcher: matcher}, func(req *http.Request) {}))
+}
+
+func TestCassetteErrors(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	dir := t.TempDir()
+	cassette, err := NewCassette(filepath.Join(dir, "missing.yaml"), &CassetteOptions{Mode: CassetteModeReplay})
+	assert.Nil(t, cassette)
+	assert.NotNil(t, err)
+
+	filename := filepath.Join(dir, "invalid.json")
+	assert.Nil(t, os.WriteFile(filename, []byte("{"), 0600))
+	cassette, err = NewCassette(filename, &CassetteOptions{Mode: CassetteModeReplay})
+	assert.Nil(t, cassette)
+	assert.NotNil(t, err)
+
+	// In record mode, the file needn't exist (but its directory must, for it to be saved).
+	cassette, err = NewCassette(filepath.Join(dir, "missing", "cassette.yaml"), nil)
+	assert.Nil(t, err)
+	assert.NotNil(t, cassette.Save())
+}
+
+func TestStubTokenResponse(t *testing.T) {
+	// VPC tokens expire at a given time.
+	stubbed, ok := stubTokenResponse([]byte(`{"access_token": "token", "created_at": "2026-01-01T00:00:00Z", "expires_at": "2026-01-01T00:05:00Z", "expires_in": 300}`))
+	assert.True(t, ok)
+	var response map[string]interface{}
+	assert.Nil(t, json.Unmarshal(stubbed, &response))
+	assert.Equal(t, "2100-01-01T00:00:00Z", response["expires_at"])
+	assert.Equal(t, float64(300), response["expires_in"])
+	assert.Equal(t, newCassetteToken(), response["access_token"])
+
+	// CP4D and MCSP tokens expire as the JWT does.
+	stubbed, ok = stubTokenResponse([]byte(`{"token": "token", "_messageCode_": "200"}`))
+	assert.True(t, ok)
+	assert.Equal(t, `{"_messageCode_":"200","token":"`+newCassetteToken()+`"}`, string(stubbed))
+
+	_, ok = stubTokenResponse([]byte(`{"name": "a"}`))
+	assert.False(t, ok)
+	_, ok = stubTokenResponse([]byte(`not json`))
+	assert.False(t, ok)
+}
+
+func TestCassetteTokenResponses(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	responseBody := `{"token": "my-token", "expiration": 9007199254740993, "id": "abc"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(CONTENT_TYPE, APPLICATION_JSON)
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, responseBody)
+	}))
+	defer server.Close()
+
+	cassette, err := NewCassette(filepath.Join(t.TempDir(), "cassette.json"), nil)
+	assert.Nil(t, err)
+	client := cassette.Client()
+	for _, path := range []string{
+		"/instances",
+		"/siusermgr/api/1.0/apikeys/token",
+		"/api/2.0/accounts/my-account/apikeys/token",
+		"/icp4d-api/v1/authorize",
+	} {
+		resp, err := client.Post(server.URL+path, APPLICATION_JSON, strings.NewReader("{}"))
+		assert.Nil(t, err)
+		_ = resp.Body.Close()
+	}
+	interactions := cassette.Interactions()
+	assert.Len(t, interactions, 4)
+
+	// Only the responses to the authenticators' token requests are stubbed;
+	// other responses are recorded as they are, but for their secrets.
+	assert.Equal(t, RedactSecrets(responseBody), interactions[0].Response.Body)
+	assert.Contains(t, interactions[0].Response.Body, `"expiration": 9007199254740993, "id": "abc"`)
+	for _, interaction := range interactions[1:] {
+		assert.NotContains(t, interaction.Response.Body, "my-token")
+		assert.Contains(t, interaction.Response.Body, `"token":"`+newCassetteToken()+`"`)
+		assert.Contains(t, interaction.Response.Body, `"expiration":4102444800`)
+		assert.Contains(t, interaction.Response.Body, `"id":"abc"`)
+	}
+}
+
+func TestCassetteCompressedBodies(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	gzipped := func(s string) []byte {
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		_, _ = writer.Write([]byte(s))
+		_ = writer.Close()
+		return buf.Bytes()
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/brotli" {
+			w.Header().Set(CONTENT_ENCODING, "br")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte{0x0b, 0x01, 0x80})
+			return
+		}
+		w.Header().Set(CONTENT_TYPE, APPLICATION_JSON)
+		w.Header().Set(CONTENT_ENCODING, "gzip")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(gzipped(`{"access_token": "real-token", "expires_in": 60}`))
+	}))
+	defer server.Close()
+
+	filename := filepath.Join(t.TempDir(), "cassette.json")
+	cassette, err := NewCassette(filename, &CassetteOptions{})
+	assert.Nil(t, err)
+	client := cassette.Client()
+
+	// Compressed bodies are recorded decompressed, with their secrets redacted and their tokens stubbed.
+	req, _ := http.NewRequest(POST, server.URL+"/identity/token", bytes.NewReader(gzipped(`{"password": "my-password"}`)))
+	req.Header.Set(CONTENT_ENCODING, "gzip")
+	req.Header.Set(headerNameAcceptEncoding, "gzip")
+	resp, err := client.Do(req)
+	assert.Nil(t, err)
+	_ = resp.Body.Close()
+
+	interaction := cassette.Interactions()[0]
+	assert.Contains(t, interaction.Request.Body, `"password":"[redacted]"`)
+	assert.Empty(t, interaction.Request.BodyEncoding)
+	assert.Empty(t, interaction.Request.Headers.Get(CONTENT_ENCODING))
+	assert.NotContains(t, interaction.Response.Body, "real-token")
+	assert.Contains(t, interaction.Response.Body, `"access_token":"`+newCassetteToken()+`"`)
+	assert.Empty(t, interaction.Response.BodyEncoding)
+	assert.Empty(t, interaction.Response.Headers.Get(CONTENT_ENCODING))
+
+	// An interaction whose body can't be decompressed isn't recorded, but the request succeeds.
+	req, _ = http.NewRequest(GET, server.URL+"/brotli", nil)
+	req.Header.Set(headerNameAcceptEncoding, "br")
+	resp, err = client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, []byte{0x0b, 0x01, 0x80}, body)
+	assert.Len(t, cassette.Interactions(), 1)
+
+	req, _ = http.NewRequest(POST, server.URL+"/brotli", bytes.NewReader([]byte{0x0b, 0x01, 0x80}))
+	req.Header.Set(CONTENT_ENCODING, "br")
+	resp, err = client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Len(t, cassette.Interactions(), 1)
+
+	// The decompressed response is replayed.
+	assert.Nil(t, cassette.Save())
+	cassette, err = NewCassette(filename, &CassetteOptions{Mode: CassetteModeReplay})
+	assert.Nil(t, err)
+	req, _ = http.NewRequest(POST, server.URL+"/identity/token", bytes.NewReader(gzipped(`{"password": "another-password"}`)))
+	req.Header.Set(CONTENT_ENCODING, "gzip")
+	resp, err = cassette.Client().Do(req)
+	assert.Nil(t, err)
+	body, _ = io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), newCassetteToken())
+}
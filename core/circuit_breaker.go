@@ -0,0 +1,249 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCircuitBreakerFailureThreshold is the default number of consecutive
+	// failures that will open the circuit for a host.
+	DefaultCircuitBreakerFailureThreshold = 5
+
+	// DefaultCircuitBreakerCoolDown is the default amount of time that the circuit
+	// for a host stays open before a trial request is allowed.
+	DefaultCircuitBreakerCoolDown = 30 * time.Second
+
+	ERRORMSG_CIRCUIT_OPEN = "The request was not sent because the circuit breaker for host '%s' is open"
+)
+
+// CircuitState is the state of the circuit maintained by a CircuitBreaker for a host.
+type CircuitState int
+
+const (
+	// CircuitClosed indicates that requests are sent to the host normally.
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen indicates that requests to the host fail immediately
+	// without being sent, until the cool-down period has elapsed.
+	CircuitOpen
+
+	// CircuitHalfOpen indicates that a limited number of trial requests are being sent
+	// to the host to determine whether the circuit should be closed or re-opened.
+	CircuitHalfOpen
+)
+
+func (state CircuitState) String() string {
+	switch state {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitBreaker tracks the failures of the requests sent to each host by one or more
+// BaseService instances (see ServiceOptions.CircuitBreaker), and stops sending requests
+// to a host that keeps failing.
+//
+// A failure is a request (or retry attempt) that could not be sent, that timed out or that
+// received a 5xx status code other than 501 (Not Implemented); a request cancelled by the caller
+// is not counted. Once "failureThreshold" consecutive failures have occurred, the circuit for
+// the host is opened: new requests fail immediately
+// with an error whose discriminator is "circuit-open" and in-progress requests are not retried.
+// After the cool-down period, the circuit becomes half-open and a limited number of trial
+// requests are sent; the circuit is closed if they succeed, or re-opened if one fails.
+//
+// A CircuitBreaker is safe for concurrent use and may be shared by multiple services.
+type CircuitBreaker struct {
+	mutex               sync.Mutex
+	failureThreshold    int
+	coolDown            time.Duration
+	halfOpenMaxRequests int
+	circuits            map[string]*circuit
+
+	// now returns the current time; it can be replaced by tests.
+	now func() time.Time
+}
+
+// circuit is the state of the circuit for a single host.
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+
+	// trials is the number of trial requests in progress while half-open.
+	trials int
+}
+
+// NewCircuitBreaker returns a new CircuitBreaker that opens the circuit for a host after
+// "failureThreshold" consecutive failures and keeps it open for "coolDown".
+// If "failureThreshold" and/or "coolDown" are specified as 0, then default values
+// are used instead.
+func NewCircuitBreaker(failureThreshold int, coolDown time.Duration) *CircuitBreaker {
+	if failureThreshold <= 0 {
+		failureThreshold = DefaultCircuitBreakerFailureThreshold
+	}
+	if coolDown <= 0 {
+		coolDown = DefaultCircuitBreakerCoolDown
+	}
+	return &CircuitBreaker{
+		failureThreshold:    failureThreshold,
+		coolDown:            coolDown,
+		halfOpenMaxRequests: 1,
+		circuits:            make(map[string]*circuit),
+		now:                 time.Now,
+	}
+}
+
+// WithHalfOpenMaxRequests sets the maximum number of trial requests that may be
+// in progress while the circuit for a host is half-open (the default is 1).
+func (breaker *CircuitBreaker) WithHalfOpenMaxRequests(maxRequests int) *CircuitBreaker {
+	if maxRequests > 0 {
+		breaker.halfOpenMaxRequests = maxRequests
+	}
+	return breaker
+}
+
+// GetFailureThreshold returns the number of consecutive failures that open the circuit for a host.
+func (breaker *CircuitBreaker) GetFailureThreshold() int {
+	return breaker.failureThreshold
+}
+
+// GetCoolDown returns the amount of time that the circuit for a host stays open.
+func (breaker *CircuitBreaker) GetCoolDown() time.Duration {
+	return breaker.coolDown
+}
+
+// State returns the current state of the circuit for "host".
+func (breaker *CircuitBreaker) State(host string) CircuitState {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	c, ok := breaker.circuits[host]
+	if !ok {
+		return CircuitClosed
+	}
+	if c.state == CircuitOpen && !breaker.now().Before(c.openedAt.Add(breaker.coolDown)) {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+// Reset closes the circuit for each host.
+func (breaker *CircuitBreaker) Reset() {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	breaker.circuits = make(map[string]*circuit)
+}
+
+// allow returns true if a new request may be sent to "host".
+func (breaker *CircuitBreaker) allow(host string) bool {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	c, ok := breaker.circuits[host]
+	if !ok {
+		return true
+	}
+
+	if c.state == CircuitOpen {
+		if breaker.now().Before(c.openedAt.Add(breaker.coolDown)) {
+			return false
+		}
+		GetLogger().Debug("Circuit for host '%s' is half-open\n", host)
+		c.state = CircuitHalfOpen
+		c.trials = 0
+	}
+
+	if c.state == CircuitHalfOpen {
+		if c.trials >= breaker.halfOpenMaxRequests {
+			return false
+		}
+		c.trials++
+	}
+	return true
+}
+
+// release gives up the trial slot (if any) taken by allow for a request to "host" that was not sent.
+func (breaker *CircuitBreaker) release(host string) {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	if c, ok := breaker.circuits[host]; ok && c.state == CircuitHalfOpen && c.trials > 0 {
+		c.trials--
+	}
+}
+
+// record updates the circuit for "host" with the outcome of a request (or retry attempt).
+func (breaker *CircuitBreaker) record(host string, resp *http.Response, err error) {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	c, ok := breaker.circuits[host]
+	if !ok {
+		c = &circuit{}
+		breaker.circuits[host] = c
+	}
+
+	// A request cancelled by the caller tells us nothing about the host, so just give up its trial slot.
+	// A request that timed out, on the other hand, is a failure.
+	var timeoutErr *timeoutError
+	if err != nil && errors.Is(err, context.Canceled) && !errors.As(err, &timeoutErr) {
+		if c.state == CircuitHalfOpen && c.trials > 0 {
+			c.trials--
+		}
+		return
+	}
+
+	failure := err != nil || (resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented)
+	switch c.state {
+	case CircuitClosed:
+		if !failure {
+			c.failures = 0
+			return
+		}
+		c.failures++
+		if c.failures >= breaker.failureThreshold {
+			breaker.open(host, c)
+		}
+	case CircuitHalfOpen:
+		if failure {
+			breaker.open(host, c)
+			return
+		}
+		GetLogger().Debug("Circuit for host '%s' is closed\n", host)
+		c.state = CircuitClosed
+		c.failures = 0
+		c.trials = 0
+	}
+}
+
+func (breaker *CircuitBreaker) open(host string, c *circuit) {
+	GetLogger().Debug("Circuit for host '%s' is open\n", host)
+	c.state = CircuitOpen
+	c.openedAt = breaker.now()
+	c.failures = 0
+	c.trials = 0
+}
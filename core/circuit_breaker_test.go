@@ -0,0 +1,328 @@
+//go:build all || fast || basesvc || retries
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCircuitBreaker returns a CircuitBreaker whose clock is controlled by the returned function.
+func newTestCircuitBreaker(failureThreshold int, coolDown time.Duration) (*CircuitBreaker, func(time.Duration)) {
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(failureThreshold, coolDown)
+	breaker.now = func() time.Time {
+		return now
+	}
+	return breaker, func(d time.Duration) {
+		now = now.Add(d)
+	}
+}
+
+func TestCircuitBreakerStates(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	breaker, advance := newTestCircuitBreaker(3, time.Minute)
+	assert.Equal(t, 3, breaker.GetFailureThreshold())
+	assert.Equal(t, time.Minute, breaker.GetCoolDown())
+
+	failure := &http.Response{StatusCode: http.StatusServiceUnavailable}
+	success := &http.Response{StatusCode: http.StatusOK}
+	host := "myservice.cloud.ibm.com"
+
+	// A success resets the count of consecutive failures.
+	assert.True(t, breaker.allow(host))
+	breaker.record(host, failure, nil)
+	breaker.record(host, nil, errors.New("connection reset"))
+	breaker.record(host, success, nil)
+	breaker.record(host, failure, nil)
+	breaker.record(host, failure, nil)
+	assert.Equal(t, CircuitClosed, breaker.State(host))
+
+	// Client errors and 501 don't count as failures.
+	breaker.record(host, &http.Response{StatusCode: http.StatusNotImplemented}, nil)
+	breaker.record(host, &http.Response{StatusCode: http.StatusNotFound}, nil)
+	assert.Equal(t, CircuitClosed, breaker.State(host))
+
+	breaker.record(host, failure, nil)
+	breaker.record(host, failure, nil)
+	breaker.record(host, failure, nil)
+	assert.Equal(t, CircuitOpen, breaker.State(host))
+	assert.Equal(t, "open", breaker.State(host).String())
+	assert.False(t, breaker.allow(host))
+
+	// Other hosts are not affected.
+	assert.Equal(t, CircuitClosed, breaker.State("other.cloud.ibm.com"))
+	assert.True(t, breaker.allow("other.cloud.ibm.com"))
+
+	// After the cool-down, a single trial request is allowed.
+	advance(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, breaker.State(host))
+	assert.True(t, breaker.allow(host))
+	assert.False(t, breaker.allow(host))
+
+	// A failed trial request re-opens the circuit.
+	breaker.record(host, failure, nil)
+	assert.Equal(t, CircuitOpen, breaker.State(host))
+	assert.False(t, breaker.allow(host))
+
+	// A cancelled trial request gives up its slot.
+	advance(time.Minute)
+	assert.True(t, breaker.allow(host))
+	breaker.record(host, nil, &url.Error{Op: "Get", URL: host, Err: context.Canceled})
+	assert.Equal(t, CircuitHalfOpen, breaker.State(host))
+
+	// A successful trial request closes the circuit.
+	assert.True(t, breaker.allow(host))
+	breaker.record(host, success, nil)
+	assert.Equal(t, CircuitClosed, breaker.State(host))
+	assert.Equal(t, "closed", breaker.State(host).String())
+	assert.True(t, breaker.allow(host))
+	assert.True(t, breaker.allow(host))
+
+	breaker.record(host, failure, nil)
+	breaker.record(host, failure, nil)
+	breaker.record(host, failure, nil)
+	assert.Equal(t, CircuitOpen, breaker.State(host))
+	breaker.Reset()
+	assert.Equal(t, CircuitClosed, breaker.State(host))
+}
+
+func TestCircuitBreakerHalfOpenMaxRequests(t *testing.T) {
+	breaker, advance := newTestCircuitBreaker(0, 0)
+	breaker.WithHalfOpenMaxRequests(2)
+	assert.Equal(t, DefaultCircuitBreakerFailureThreshold, breaker.GetFailureThreshold())
+	assert.Equal(t, DefaultCircuitBreakerCoolDown, breaker.GetCoolDown())
+
+	for i := 0; i < DefaultCircuitBreakerFailureThreshold; i++ {
+		breaker.record("host", nil, errors.New("connection refused"))
+	}
+	assert.False(t, breaker.allow("host"))
+
+	advance(DefaultCircuitBreakerCoolDown)
+	assert.True(t, breaker.allow("host"))
+	assert.True(t, breaker.allow("host"))
+	assert.False(t, breaker.allow("host"))
+	assert.Equal(t, "half-open", breaker.State("host").String())
+}
+
+func TestCircuitBreakerOpenError(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+	service.SetCircuitBreaker(NewCircuitBreaker(2, time.Minute))
+	assert.NotNil(t, service.GetCircuitBreaker())
+
+	builder := NewRequestBuilder(GET)
+	_, _ = builder.ResolveRequestURL(server.URL, "/instances", nil)
+	req, _ := builder.Build()
+
+	for i := 0; i < 2; i++ {
+		detailedResponse, err := service.Request(req, nil)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusInternalServerError, detailedResponse.StatusCode)
+	}
+
+	// The circuit is now open, so the request is not sent.
+	detailedResponse, err := service.Request(req, nil)
+	assert.Nil(t, detailedResponse)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "circuit breaker for host")
+	sdkProblem, ok := err.(*SDKProblem)
+	assert.True(t, ok)
+	assert.Equal(t, "circuit-open", sdkProblem.discriminator)
+	assert.Equal(t, 2, requests)
+
+	// The circuit breaker is shared by clones of the service.
+	_, err = service.Clone().Request(req, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, requests)
+}
+
+func TestCircuitBreakerStopsRetries(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:            server.URL,
+		Authenticator:  &NoAuthAuthenticator{},
+		CircuitBreaker: NewCircuitBreaker(3, time.Minute),
+	})
+	assert.Nil(t, err)
+	service.EnableRetries(5, 10*time.Millisecond)
+
+	builder := NewRequestBuilder(GET)
+	_, _ = builder.ResolveRequestURL(server.URL, "/instances", nil)
+	req, _ := builder.Build()
+
+	// Each attempt counts as a failure, so the circuit opens after the third attempt.
+	detailedResponse, err := service.Request(req, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, detailedResponse.StatusCode)
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, CircuitOpen, service.GetCircuitBreaker().State(req.URL.Host))
+}
+
+func TestConfigureServiceCircuitBreaker(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           "https://myservice/api",
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+
+	t.Setenv("CATALOG_SERVICE_ENABLE_CIRCUIT_BREAKER", "false")
+	assert.Nil(t, service.ConfigureService("catalog_service"))
+	assert.Nil(t, service.GetCircuitBreaker())
+
+	t.Setenv("CATALOG_SERVICE_ENABLE_CIRCUIT_BREAKER", "true")
+	t.Setenv("CATALOG_SERVICE_CIRCUIT_BREAKER_FAILURE_THRESHOLD", "10")
+	t.Setenv("CATALOG_SERVICE_CIRCUIT_BREAKER_COOL_DOWN", "60")
+	assert.Nil(t, service.ConfigureService("catalog_service"))
+	breaker := service.GetCircuitBreaker()
+	assert.NotNil(t, breaker)
+	assert.Equal(t, 10, breaker.GetFailureThreshold())
+	assert.Equal(t, time.Minute, breaker.GetCoolDown())
+
+	t.Setenv("CATALOG_SERVICE_CIRCUIT_BREAKER_FAILURE_THRESHOLD", "ten")
+	assert.Nil(t, service.ConfigureService("catalog_service"))
+	assert.Equal(t, DefaultCircuitBreakerFailureThreshold, service.GetCircuitBreaker().GetFailureThreshold())
+}
+
+func TestCircuitBreakerRateLimiterWaitFails(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	breaker, advance := newTestCircuitBreaker(1, time.Minute)
+	limiter := NewRateLimiter(0.1, 1)
+	service, err := NewBaseService(&ServiceOptions{
+		URL:            server.URL,
+		Authenticator:  &NoAuthAuthenticator{},
+		CircuitBreaker: breaker,
+		RateLimiter:    limiter,
+	})
+	assert.Nil(t, err)
+
+	builder := NewRequestBuilder(GET)
+	_, _ = builder.ResolveRequestURL(server.URL, "/instances", nil)
+	req, _ := builder.Build()
+	host := req.URL.Host
+
+	// Open the circuit, then let it become half-open.
+	breaker.record(host, nil, errors.New("connection refused"))
+	advance(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, breaker.State(host))
+
+	// Use up the limiter's quota, so that requests wait until their Context is done.
+	_, ok := limiter.reserve(false)
+	assert.True(t, ok)
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err = service.Request(req.WithContext(ctx), nil)
+		cancel()
+		assert.NotNil(t, err)
+		assert.Equal(t, "rate-limit-wait-canceled", err.(*SDKProblem).discriminator)
+	}
+
+	// The trial slot was given back each time, so a trial request can still close the circuit.
+	service.SetRateLimiter(nil)
+	_, err = service.Request(req, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, CircuitClosed, breaker.State(host))
+}
+
+func TestCircuitBreakerTimeouts(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	breaker, _ := newTestCircuitBreaker(2, time.Minute)
+	host := "myservice"
+
+	// Only the requests cancelled by the caller aren't failures.
+	breaker.record(host, nil, &url.Error{Op: "Get", URL: host, Err: context.Canceled})
+	breaker.record(host, nil, &url.Error{Op: "Get", URL: host, Err: context.Canceled})
+	assert.Equal(t, CircuitClosed, breaker.State(host))
+	breaker.record(host, nil, &url.Error{Op: "Get", URL: host, Err: context.DeadlineExceeded})
+	breaker.record(host, nil, &timeoutError{discriminator: "attempt-timeout", message: ERRORMSG_ATTEMPT_TIMEOUT})
+	assert.Equal(t, CircuitOpen, breaker.State(host))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	builder := NewRequestBuilder(GET)
+	_, _ = builder.ResolveRequestURL(server.URL, "/instances", nil)
+	req, _ := builder.Build()
+
+	// A host whose requests only time out trips the breaker.
+	for _, timeouts := range []RequestTimeouts{{Total: 20 * time.Millisecond}, {Attempt: 20 * time.Millisecond}} {
+		service, err := NewBaseService(&ServiceOptions{
+			URL:            server.URL,
+			Authenticator:  &NoAuthAuthenticator{},
+			CircuitBreaker: NewCircuitBreaker(2, time.Minute),
+		})
+		assert.Nil(t, err)
+		service.SetTimeouts(timeouts)
+		for i := 0; i < 2; i++ {
+			_, err = service.Request(req, nil)
+			assert.NotNil(t, err)
+		}
+		assert.Equal(t, CircuitOpen, service.GetCircuitBreaker().State(req.URL.Host))
+		_, err = service.Request(req, nil)
+		assert.Equal(t, "circuit-open", err.(*SDKProblem).discriminator)
+	}
+
+	// A request cancelled by the caller doesn't.
+	service, err := NewBaseService(&ServiceOptions{
+		URL:            server.URL,
+		Authenticator:  &NoAuthAuthenticator{},
+		CircuitBreaker: NewCircuitBreaker(1, time.Minute),
+	})
+	assert.Nil(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	_, err = service.Request(req.WithContext(ctx), nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, CircuitClosed, service.GetCircuitBreaker().State(req.URL.Host))
+}
@@ -0,0 +1,418 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// Supported content encodings.
+	ENCODING_GZIP    = "gzip"
+	ENCODING_DEFLATE = "deflate"
+	ENCODING_ZSTD    = "zstd"
+
+	headerNameAcceptEncoding = "Accept-Encoding"
+
+	// COMPRESSION_NONE may be used as the value of the COMPRESSION configuration
+	// property to disable request body compression.
+	COMPRESSION_NONE = "none"
+
+	ERRORMSG_DECOMPRESS_RESPONSE     = "An error occurred while decompressing the '%s' encoded response body: %s"
+	ERRORMSG_COMPRESSION_UNSUPPORTED = "Unsupported compression codec '%s'. Supported values are: %s"
+	ERRORMSG_COMPRESSION_LEVEL       = "Invalid compression level %d for codec '%s', the level must be between %d and %d"
+	ERRORMSG_COMPRESSION_MIN_BYTES   = "The minimum body size for compression cannot be negative"
+)
+
+// CompressionCodec is implemented by the codecs that can be used to compress request bodies.
+// A codec is configured on a service via ServiceOptions.Compression (or the COMPRESSION
+// configuration property), or on an individual request via RequestBuilder.Compression.
+type CompressionCodec interface {
+	// Encoding returns the value of the "Content-Encoding" header for bodies compressed by this codec.
+	Encoding() string
+
+	// Level returns the codec's compression level (0 means the codec's default level).
+	Level() int
+
+	// MinBytes returns the size of the smallest body that will be compressed.
+	// Bodies with fewer bytes are sent uncompressed.
+	MinBytes() int64
+
+	// NewWriter returns a WriteCloser that writes the compressed form of the data written to it to "w".
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+}
+
+// compressionCodec is the CompressionCodec implementation shared by the supported encodings.
+type compressionCodec struct {
+	encoding  string
+	level     int
+	minBytes  int64
+	newWriter func(w io.Writer, level int) (io.WriteCloser, error)
+}
+
+func (codec *compressionCodec) Encoding() string {
+	return codec.encoding
+}
+
+func (codec *compressionCodec) Level() int {
+	return codec.level
+}
+
+func (codec *compressionCodec) MinBytes() int64 {
+	return codec.minBytes
+}
+
+func (codec *compressionCodec) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return codec.newWriter(w, codec.level)
+}
+
+// NewGzipCodec returns a CompressionCodec that compresses bodies using gzip.
+// "level" must be between 1 (best speed) and 9 (best compression), or 0 for the default level.
+// Bodies with fewer than "minBytes" bytes are sent uncompressed.
+func NewGzipCodec(level int, minBytes int64) (CompressionCodec, error) {
+	codec, err := newCompressionCodec(ENCODING_GZIP, level, minBytes, flate.BestSpeed, flate.BestCompression,
+		func(w io.Writer, level int) (io.WriteCloser, error) {
+			if level == 0 {
+				level = gzip.DefaultCompression
+			}
+			return gzip.NewWriterLevel(w, level)
+		})
+	return codec, RepurposeSDKProblem(err, "")
+}
+
+// NewDeflateCodec returns a CompressionCodec that compresses bodies using
+// the zlib-wrapped deflate format (RFC 1950), as required for the "deflate" content encoding.
+// "level" must be between 1 (best speed) and 9 (best compression), or 0 for the default level.
+// Bodies with fewer than "minBytes" bytes are sent uncompressed.
+func NewDeflateCodec(level int, minBytes int64) (CompressionCodec, error) {
+	codec, err := newCompressionCodec(ENCODING_DEFLATE, level, minBytes, flate.BestSpeed, flate.BestCompression,
+		func(w io.Writer, level int) (io.WriteCloser, error) {
+			if level == 0 {
+				level = zlib.DefaultCompression
+			}
+			return zlib.NewWriterLevel(w, level)
+		})
+	return codec, RepurposeSDKProblem(err, "")
+}
+
+// NewZstdCodec returns a CompressionCodec that compresses bodies using zstd.
+// "level" must be between 1 (best speed) and 22 (best compression), or 0 for the default level.
+// Bodies with fewer than "minBytes" bytes are sent uncompressed.
+func NewZstdCodec(level int, minBytes int64) (CompressionCodec, error) {
+	codec, err := newCompressionCodec(ENCODING_ZSTD, level, minBytes, 1, 22,
+		func(w io.Writer, level int) (io.WriteCloser, error) {
+			encoderLevel := zstd.SpeedDefault
+			if level != 0 {
+				encoderLevel = zstd.EncoderLevelFromZstd(level)
+			}
+			return zstd.NewWriter(w, zstd.WithEncoderLevel(encoderLevel), zstd.WithEncoderConcurrency(1))
+		})
+	return codec, RepurposeSDKProblem(err, "")
+}
+
+// NewCompressionCodec returns the CompressionCodec for the specified content encoding
+// ("gzip", "deflate" or "zstd"), or nil if "encoding" is "none".
+func NewCompressionCodec(encoding string, level int, minBytes int64) (codec CompressionCodec, err error) {
+	switch strings.ToLower(encoding) {
+	case ENCODING_GZIP:
+		codec, err = NewGzipCodec(level, minBytes)
+	case ENCODING_DEFLATE:
+		codec, err = NewDeflateCodec(level, minBytes)
+	case ENCODING_ZSTD:
+		codec, err = NewZstdCodec(level, minBytes)
+	case COMPRESSION_NONE:
+	default:
+		err = fmt.Errorf(ERRORMSG_COMPRESSION_UNSUPPORTED, encoding,
+			strings.Join([]string{ENCODING_GZIP, ENCODING_DEFLATE, ENCODING_ZSTD, COMPRESSION_NONE}, ", "))
+		err = SDKErrorf(err, "", "unsupported-compression", getComponentInfo())
+	}
+	return
+}
+
+func newCompressionCodec(encoding string, level int, minBytes int64, minLevel int, maxLevel int,
+	newWriter func(w io.Writer, level int) (io.WriteCloser, error)) (CompressionCodec, error) {
+	if level != 0 && (level < minLevel || level > maxLevel) {
+		err := fmt.Errorf(ERRORMSG_COMPRESSION_LEVEL, level, encoding, minLevel, maxLevel)
+		return nil, SDKErrorf(err, "", "invalid-compression-level", getComponentInfo())
+	}
+	if minBytes < 0 {
+		return nil, SDKErrorf(errors.New(ERRORMSG_COMPRESSION_MIN_BYTES), "", "invalid-compression-min-bytes", getComponentInfo())
+	}
+	return &compressionCodec{
+		encoding:  encoding,
+		level:     level,
+		minBytes:  minBytes,
+		newWriter: newWriter,
+	}, nil
+}
+
+// NewCompressionReader will return an io.Reader instance that will deliver
+// the version of the "uncompressedReader" argument compressed by "codec".
+func NewCompressionReader(codec CompressionCodec, uncompressedReader io.Reader) (io.Reader, error) {
+	// As in NewGzipCompressionReader, the compressed bytes are
+	// delivered on demand through a pipe.
+	pipeReader, pipeWriter := io.Pipe()
+	compressedWriter, err := codec.NewWriter(pipeWriter)
+	if err != nil {
+		return nil, SDKErrorf(err, "", "compression-writer-error", getComponentInfo())
+	}
+	go func() {
+		defer pipeWriter.Close()
+		_, err := io.Copy(compressedWriter, uncompressedReader)
+		if err == nil {
+			err = compressedWriter.Close()
+		}
+		if err != nil {
+			sdkErr := SDKErrorf(err, "", "compression-failed", getComponentInfo())
+			_ = pipeWriter.CloseWithError(sdkErr)
+		}
+	}()
+	return pipeReader, nil
+}
+
+// compressBody returns a reader that delivers "body" compressed by "codec", unless the body
+// is smaller than the codec's minimum size, in which case the returned reader delivers the
+// uncompressed body and "compressed" is false. "size" is the length of the body if known, or -1.
+func compressBody(codec CompressionCodec, body io.Reader, size int64) (newBody io.Reader, compressed bool, err error) {
+	newBody = body
+	if minBytes := codec.MinBytes(); minBytes > 0 {
+		if size >= 0 {
+			if size < minBytes {
+				return
+			}
+		} else {
+			// The body's size isn't known, so read enough of it to determine if it meets the threshold.
+			prefix := new(bytes.Buffer)
+			_, err = io.CopyN(prefix, body, minBytes)
+			if err == io.EOF {
+				return prefix, false, nil
+			}
+			if err != nil {
+				err = SDKErrorf(err, "", "body-read-error", getComponentInfo())
+				return
+			}
+			newBody = io.MultiReader(prefix, body)
+		}
+	}
+
+	newBody, err = NewCompressionReader(codec, newBody)
+	if err != nil {
+		err = RepurposeSDKProblem(err, "compression-reader-error")
+		return
+	}
+	return newBody, true, nil
+}
+
+// bodyLength returns the length of "body" if it can be determined without reading it, or -1.
+func bodyLength(body io.Reader) int64 {
+	if lenBody, ok := body.(interface{ Len() int }); ok {
+		return int64(lenBody.Len())
+	}
+	return -1
+}
+
+// compressRequest compresses the body of "req" using "codec" if it meets the codec's
+// minimum size, and then updates the request's headers accordingly.
+func compressRequest(req *http.Request, codec CompressionCodec) error {
+	size := int64(-1)
+	if req.ContentLength > 0 {
+		size = req.ContentLength
+	}
+
+	originalBody := req.Body
+	newBody, compressed, err := compressBody(codec, originalBody, size)
+	if err != nil {
+		return err
+	}
+	req.Body = &readCloser{Reader: newBody, Closer: originalBody}
+	if !compressed {
+		return nil
+	}
+
+	GetLogger().Debug("Compressing request body using '%s' encoding", codec.Encoding())
+	req.Header.Set(CONTENT_ENCODING, codec.Encoding())
+	req.Header.Del(CONTENT_LENGTH)
+	req.ContentLength = -1
+
+	// Make sure the body can still be re-sent (e.g. when following a redirect).
+	if getBody := req.GetBody; getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			compressedBody, err := NewCompressionReader(codec, body)
+			if err != nil {
+				return nil, err
+			}
+			return &readCloser{Reader: compressedBody, Closer: body}, nil
+		}
+	}
+	return nil
+}
+
+// readCloser combines a Reader with the Closer of the body it replaces.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// acceptEncodingValue is the value of the "Accept-Encoding" header sent with each
+// request when response decompression is enabled.
+var acceptEncodingValue = strings.Join([]string{ENCODING_GZIP, ENCODING_DEFLATE, ENCODING_ZSTD}, ", ")
+
+// ResponseCompression describes a compressed response body that was
+// transparently decompressed by the BaseService.
+//
+// If the response body is returned as a stream (io.ReadCloser), the sizes reflect
+// the number of bytes read from the stream so far, and will be final once
+// the stream has been read completely.
+type ResponseCompression struct {
+	// The original value of the response's "Content-Encoding" header (e.g. "gzip").
+	Encoding string `yaml:"encoding"`
+
+	// The number of compressed bytes received from the server.
+	CompressedSize int64 `yaml:"compressed_size"`
+
+	// The number of bytes in the decompressed response body.
+	UncompressedSize int64 `yaml:"uncompressed_size"`
+}
+
+// NewDeflateDecompressionReader will return an io.Reader instance that will deliver
+// the decompressed version of the "deflate" encoded "compressedReader" argument.
+// Both zlib-wrapped (RFC 1950) and raw (RFC 1951) deflate streams are supported,
+// since servers are known to send either one for the "deflate" content encoding.
+func NewDeflateDecompressionReader(compressedReader io.Reader) (io.ReadCloser, error) {
+	bufferedReader := bufio.NewReader(compressedReader)
+	header, err := bufferedReader.Peek(2)
+	if err != nil && err != io.EOF {
+		return nil, SDKErrorf(err, "", "decompress-read-error", getComponentInfo())
+	}
+
+	// A zlib header specifies the deflate compression method and
+	// contains a checksum that is a multiple of 31.
+	if len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		res, err := zlib.NewReader(bufferedReader)
+		if err != nil {
+			return nil, SDKErrorf(err, "", "decompress-read-error", getComponentInfo())
+		}
+		return res, nil
+	}
+	return flate.NewReader(bufferedReader), nil
+}
+
+// NewZstdDecompressionReader will return an io.Reader instance that will deliver
+// the zstd-decompressed version of the "compressedReader" argument.
+func NewZstdDecompressionReader(compressedReader io.Reader) (io.ReadCloser, error) {
+	decoder, err := zstd.NewReader(compressedReader, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, SDKErrorf(err, "", "decompress-read-error", getComponentInfo())
+	}
+	return decoder.IOReadCloser(), nil
+}
+
+// newDecompressionReader returns a reader that will decompress "compressedReader"
+// according to "encoding", or nil if the encoding is not supported.
+func newDecompressionReader(encoding string, compressedReader io.Reader) (io.ReadCloser, error) {
+	switch encoding {
+	case ENCODING_GZIP, "x-gzip":
+		res, err := NewGzipDecompressionReader(compressedReader)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(res), nil
+	case ENCODING_DEFLATE:
+		return NewDeflateDecompressionReader(compressedReader)
+	case ENCODING_ZSTD:
+		return NewZstdDecompressionReader(compressedReader)
+	}
+	return nil, nil
+}
+
+// decompressResponse replaces the body of "httpResponse" with a reader that delivers
+// the decompressed body if the response's Content-Encoding is supported.
+// As with the automatic decompression performed by the net/http package,
+// the Content-Encoding and Content-Length headers are removed from the response.
+// The returned ResponseCompression (nil if the body was not compressed) keeps track
+// of the compressed and uncompressed sizes as the body is read.
+func decompressResponse(httpResponse *http.Response) (compression *ResponseCompression, err error) {
+	encoding := strings.ToLower(strings.TrimSpace(httpResponse.Header.Get(CONTENT_ENCODING)))
+	if encoding == "" || encoding == "identity" || IsNil(httpResponse.Body) || httpResponse.Body == http.NoBody ||
+		(httpResponse.Request != nil && httpResponse.Request.Method == http.MethodHead) ||
+		httpResponse.StatusCode == http.StatusNoContent || httpResponse.StatusCode == http.StatusNotModified {
+		return
+	}
+
+	compression = &ResponseCompression{
+		Encoding: httpResponse.Header.Get(CONTENT_ENCODING),
+	}
+	compressedBody := &countingReader{reader: httpResponse.Body, count: &compression.CompressedSize}
+	decompressedBody, err := newDecompressionReader(encoding, compressedBody)
+	if err != nil {
+		err = fmt.Errorf(ERRORMSG_DECOMPRESS_RESPONSE, compression.Encoding, err.Error())
+		return nil, SDKErrorf(err, "", "decompress-response-error", getComponentInfo())
+	}
+	if decompressedBody == nil {
+		GetLogger().Debug("Response body has unsupported Content-Encoding '%s', skipping decompression", compression.Encoding)
+		return nil, nil
+	}
+	GetLogger().Debug("Decompressing '%s' encoded response body", compression.Encoding)
+
+	httpResponse.Body = &decompressingBody{
+		countingReader: countingReader{reader: decompressedBody, count: &compression.UncompressedSize},
+		decompressor:   decompressedBody,
+		body:           httpResponse.Body,
+	}
+	httpResponse.Header.Del(CONTENT_ENCODING)
+	httpResponse.Header.Del(CONTENT_LENGTH)
+	httpResponse.ContentLength = -1
+	httpResponse.Uncompressed = true
+	return
+}
+
+// countingReader is an io.Reader that counts the bytes read from the wrapped reader.
+type countingReader struct {
+	reader io.Reader
+	count  *int64
+}
+
+func (r *countingReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	*r.count += int64(n)
+	return
+}
+
+// decompressingBody is the decompressed replacement for a compressed response body.
+type decompressingBody struct {
+	countingReader
+	decompressor io.Closer
+	body         io.Closer
+}
+
+func (b *decompressingBody) Close() error {
+	_ = b.decompressor.Close()
+	return b.body.Close()
+}
@@ -0,0 +1,408 @@
+//go:build all || fast || basesvc
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+// compressTestBody returns "body" compressed with the specified encoding.
+func compressTestBody(t *testing.T, encoding string, body string) []byte {
+	var buf bytes.Buffer
+	var writer io.WriteCloser
+	var err error
+	switch encoding {
+	case ENCODING_GZIP:
+		writer = gzip.NewWriter(&buf)
+	case ENCODING_DEFLATE:
+		writer = zlib.NewWriter(&buf)
+	case "raw-deflate":
+		writer, err = flate.NewWriter(&buf, flate.DefaultCompression)
+	case ENCODING_ZSTD:
+		writer, err = zstd.NewWriter(&buf)
+	}
+	assert.Nil(t, err)
+	_, err = writer.Write([]byte(body))
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+	return buf.Bytes()
+}
+
+// startCompressionTestServer starts a server that responds with "body" compressed using "encoding".
+func startCompressionTestServer(t *testing.T, statusCode int, encoding string, body string) *httptest.Server {
+	compressed := compressTestBody(t, encoding, body)
+	if encoding == "raw-deflate" {
+		encoding = ENCODING_DEFLATE
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gzip, deflate, zstd", r.Header.Get("Accept-Encoding"))
+		w.Header().Set("Content-Type", APPLICATION_JSON)
+		w.Header().Set("Content-Encoding", encoding)
+		w.WriteHeader(statusCode)
+		_, _ = w.Write(compressed)
+	}))
+}
+
+func TestResponseDecompression(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	body := `{"name": "` + strings.Repeat("catalog entry ", 500) + `"}`
+
+	for _, encoding := range []string{ENCODING_GZIP, ENCODING_DEFLATE, "raw-deflate", ENCODING_ZSTD} {
+		server := startCompressionTestServer(t, http.StatusOK, encoding, body)
+
+		service, err := NewBaseService(&ServiceOptions{
+			URL:                         server.URL,
+			Authenticator:               &NoAuthAuthenticator{},
+			EnableResponseDecompression: true,
+		})
+		assert.Nil(t, err)
+
+		var foo *Foo
+		detailedResponse, err := service.Request(buildTestRequest(t, service, NewRequestBuilder(GET), "/catalog"), &foo)
+		assert.Nil(t, err, encoding)
+		assert.NotNil(t, foo)
+		assert.Len(t, *foo.Name, 14*500)
+
+		compression := detailedResponse.Compression
+		assert.NotNil(t, compression)
+		assert.Equal(t, strings.TrimPrefix(encoding, "raw-"), compression.Encoding)
+		assert.Equal(t, int64(len(body)), compression.UncompressedSize)
+		assert.Equal(t, int64(len(compressTestBody(t, encoding, body))), compression.CompressedSize)
+		assert.Less(t, compression.CompressedSize, compression.UncompressedSize)
+		assert.Empty(t, detailedResponse.Headers.Get("Content-Encoding"))
+
+		server.Close()
+	}
+}
+
+func TestResponseDecompressionStream(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	body := strings.Repeat("line of streamed data\n", 1000)
+	server := startCompressionTestServer(t, http.StatusOK, ENCODING_ZSTD, body)
+	defer server.Close()
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+	service.SetEnableResponseDecompression(true)
+	assert.True(t, service.GetEnableResponseDecompression())
+
+	var result io.ReadCloser
+	detailedResponse, err := service.Request(buildTestRequest(t, service, NewRequestBuilder(GET), "/catalog"), &result)
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+
+	data, err := io.ReadAll(result)
+	assert.Nil(t, err)
+	assert.Nil(t, result.Close())
+	assert.Equal(t, body, string(data))
+	assert.Equal(t, int64(len(body)), detailedResponse.Compression.UncompressedSize)
+	assert.Greater(t, detailedResponse.Compression.CompressedSize, int64(0))
+}
+
+func TestResponseDecompressionErrorResponse(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startCompressionTestServer(t, http.StatusBadRequest, ENCODING_GZIP, `{"error": "bad catalog query"}`)
+	defer server.Close()
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:                         server.URL,
+		Authenticator:               &NoAuthAuthenticator{},
+		EnableResponseDecompression: true,
+	})
+	assert.Nil(t, err)
+
+	detailedResponse, err := service.Request(buildTestRequest(t, service, NewRequestBuilder(GET), "/catalog"), nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, "bad catalog query", err.Error())
+	assert.Equal(t, ENCODING_GZIP, detailedResponse.Compression.Encoding)
+}
+
+func TestResponseDecompressionInvalidBody(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", APPLICATION_JSON)
+		w.Header().Set("Content-Encoding", "gzip")
+		fmt.Fprint(w, `{"name": "not compressed"}`)
+	}))
+	defer server.Close()
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:                         server.URL,
+		Authenticator:               &NoAuthAuthenticator{},
+		EnableResponseDecompression: true,
+	})
+	assert.Nil(t, err)
+
+	var foo *Foo
+	detailedResponse, err := service.Request(buildTestRequest(t, service, NewRequestBuilder(GET), "/catalog"), &foo)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "An error occurred while decompressing the 'gzip' encoded response body")
+	assert.Equal(t, http.StatusOK, detailedResponse.StatusCode)
+}
+
+func TestResponseDecompressionUnsupportedEncoding(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Encoding", "br")
+		fmt.Fprint(w, "brotli data")
+	}))
+	defer server.Close()
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:                         server.URL,
+		Authenticator:               &NoAuthAuthenticator{},
+		EnableResponseDecompression: true,
+	})
+	assert.Nil(t, err)
+
+	var result []byte
+	detailedResponse, err := service.Request(buildTestRequest(t, service, NewRequestBuilder(GET), "/catalog"), &result)
+	assert.Nil(t, err)
+	assert.Equal(t, "brotli data", string(result))
+	assert.Nil(t, detailedResponse.Compression)
+	assert.Equal(t, "br", detailedResponse.Headers.Get("Content-Encoding"))
+}
+
+func TestResponseDecompressionDisabled(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The net/http package asks for gzip by default.
+		assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+		w.Header().Set("Content-Type", APPLICATION_JSON)
+		fmt.Fprint(w, `{"name": "plain"}`)
+	}))
+	defer server.Close()
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+
+	var foo *Foo
+	detailedResponse, err := service.Request(buildTestRequest(t, service, NewRequestBuilder(GET), "/catalog"), &foo)
+	assert.Nil(t, err)
+	assert.Equal(t, "plain", *foo.Name)
+	assert.Nil(t, detailedResponse.Compression)
+}
+
+func TestConfigureServiceEnableDecompression(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           "https://myservice/api",
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+
+	t.Setenv("CATALOG_SERVICE_ENABLE_DECOMPRESSION", "true")
+	assert.Nil(t, service.ConfigureService("catalog_service"))
+	assert.True(t, service.GetEnableResponseDecompression())
+
+	t.Setenv("CATALOG_SERVICE_ENABLE_DECOMPRESSION", "notabool")
+	assert.Nil(t, service.ConfigureService("catalog_service"))
+	assert.True(t, service.GetEnableResponseDecompression())
+}
+
+// decompressTestBody decompresses "body" according to "encoding".
+func decompressTestBody(t *testing.T, encoding string, body io.Reader) string {
+	reader, err := newDecompressionReader(encoding, body)
+	assert.Nil(t, err)
+	assert.NotNil(t, reader)
+	data, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+	return string(data)
+}
+
+func TestCompressionCodecs(t *testing.T) {
+	body := strings.Repeat("compress me please ", 100)
+	for _, encoding := range []string{ENCODING_GZIP, ENCODING_DEFLATE, ENCODING_ZSTD} {
+		for _, level := range []int{0, 1, 9} {
+			codec, err := NewCompressionCodec(encoding, level, 0)
+			assert.Nil(t, err)
+			assert.Equal(t, encoding, codec.Encoding())
+			assert.Equal(t, level, codec.Level())
+
+			compressed, err := NewCompressionReader(codec, strings.NewReader(body))
+			assert.Nil(t, err)
+			assert.Equal(t, body, decompressTestBody(t, encoding, compressed))
+		}
+	}
+
+	codec, err := NewCompressionCodec("none", 0, 0)
+	assert.Nil(t, err)
+	assert.Nil(t, codec)
+}
+
+func TestCompressionCodecErrors(t *testing.T) {
+	_, err := NewCompressionCodec("br", 0, 0)
+	assert.NotNil(t, err)
+	assert.Equal(t, "Unsupported compression codec 'br'. Supported values are: gzip, deflate, zstd, none", err.Error())
+
+	_, err = NewGzipCodec(10, 0)
+	assert.NotNil(t, err)
+	assert.Equal(t, "Invalid compression level 10 for codec 'gzip', the level must be between 1 and 9", err.Error())
+
+	_, err = NewZstdCodec(23, 0)
+	assert.NotNil(t, err)
+
+	_, err = NewZstdCodec(22, 0)
+	assert.Nil(t, err)
+
+	_, err = NewDeflateCodec(0, -1)
+	assert.NotNil(t, err)
+	assert.Equal(t, ERRORMSG_COMPRESSION_MIN_BYTES, err.Error())
+}
+
+func TestRequestBuilderCompression(t *testing.T) {
+	codec, err := NewZstdCodec(0, 64)
+	assert.Nil(t, err)
+
+	// A body that meets the minimum size is compressed.
+	body := strings.Repeat("x", 64)
+	builder := NewRequestBuilder(POST)
+	builder.Compression = codec
+	builder.EnableGzipCompression = true
+	_, _ = builder.ConstructHTTPURL("test.com", nil, nil)
+	_, _ = builder.SetBodyContentString(body)
+	request, err := builder.Build()
+	assert.Nil(t, err)
+	assert.Equal(t, ENCODING_ZSTD, request.Header.Get(CONTENT_ENCODING))
+	assert.Equal(t, body, decompressTestBody(t, ENCODING_ZSTD, request.Body))
+
+	// A smaller body is sent uncompressed, even though gzip is enabled.
+	builder = NewRequestBuilder(POST)
+	builder.Compression = codec
+	builder.EnableGzipCompression = true
+	_, _ = builder.ConstructHTTPURL("test.com", nil, nil)
+	_, _ = builder.SetBodyContentString("tiny")
+	request, err = builder.Build()
+	assert.Nil(t, err)
+	assert.Empty(t, request.Header.Get(CONTENT_ENCODING))
+	assert.Equal(t, int64(4), request.ContentLength)
+	data, _ := io.ReadAll(request.Body)
+	assert.Equal(t, "tiny", string(data))
+}
+
+func TestRequestBuilderCompressionStream(t *testing.T) {
+	codec, err := NewDeflateCodec(0, 10)
+	assert.Nil(t, err)
+
+	// The size of a stream isn't known, so part of it is read to check the minimum size.
+	for _, body := range []string{"too small", "this body is large enough"} {
+		builder := NewRequestBuilder(POST)
+		builder.Compression = codec
+		_, _ = builder.ConstructHTTPURL("test.com", nil, nil)
+		_, _ = builder.SetBodyContentStream(io.MultiReader(strings.NewReader(body)))
+		request, err := builder.Build()
+		assert.Nil(t, err)
+
+		if len(body) < 10 {
+			assert.Empty(t, request.Header.Get(CONTENT_ENCODING))
+			data, _ := io.ReadAll(request.Body)
+			assert.Equal(t, body, string(data))
+		} else {
+			assert.Equal(t, ENCODING_DEFLATE, request.Header.Get(CONTENT_ENCODING))
+			assert.Equal(t, body, decompressTestBody(t, ENCODING_DEFLATE, request.Body))
+		}
+	}
+}
+
+func TestServiceRequestCompression(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	var encodings []string
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := r.Header.Get(CONTENT_ENCODING)
+		encodings = append(encodings, encoding)
+		if encoding != "" {
+			bodies = append(bodies, decompressTestBody(t, encoding, r.Body))
+		} else {
+			data, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(data))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	codec, err := NewGzipCodec(9, 100)
+	assert.Nil(t, err)
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+		Compression:   codec,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, codec, service.GetCompression())
+
+	largeBody := map[string]string{"description": strings.Repeat("large ", 50)}
+	for _, body := range []interface{}{largeBody, map[string]string{"description": "small"}} {
+		builder := NewRequestBuilder(POST)
+		_, _ = builder.ResolveRequestURL(server.URL, "/resources", nil)
+		_, _ = builder.SetBodyContentJSON(body)
+		req, _ := builder.Build()
+		_, err = service.Request(req, nil)
+		assert.Nil(t, err)
+	}
+
+	assert.Equal(t, []string{ENCODING_GZIP, ""}, encodings)
+	assert.Equal(t, toJSON(largeBody), bodies[0])
+	assert.Equal(t, "{\"description\":\"small\"}\n", bodies[1])
+}
+
+func TestConfigureServiceCompression(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           "https://myservice/api",
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+
+	t.Setenv("UPLOAD_SERVICE_COMPRESSION", "zstd")
+	t.Setenv("UPLOAD_SERVICE_COMPRESSION_LEVEL", "3")
+	t.Setenv("UPLOAD_SERVICE_COMPRESSION_MIN_BYTES", "1024")
+	assert.Nil(t, service.ConfigureService("upload_service"))
+	codec := service.GetCompression()
+	assert.NotNil(t, codec)
+	assert.Equal(t, ENCODING_ZSTD, codec.Encoding())
+	assert.Equal(t, 3, codec.Level())
+	assert.Equal(t, int64(1024), codec.MinBytes())
+
+	t.Setenv("UPLOAD_SERVICE_COMPRESSION", "none")
+	assert.Nil(t, service.ConfigureService("upload_service"))
+	assert.Nil(t, service.GetCompression())
+
+	t.Setenv("UPLOAD_SERVICE_COMPRESSION", "lz4")
+	err = service.ConfigureService("upload_service")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Unsupported compression codec 'lz4'")
+}
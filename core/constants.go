@@ -31,12 +31,26 @@ const (
 	// Example:  export MYSERVICE_URL=https://myurl
 
 	// Service client properties.
-	PROPNAME_SVC_URL            = "URL"
-	PROPNAME_SVC_DISABLE_SSL    = "DISABLE_SSL"
-	PROPNAME_SVC_ENABLE_GZIP    = "ENABLE_GZIP"
-	PROPNAME_SVC_ENABLE_RETRIES = "ENABLE_RETRIES"
-	PROPNAME_SVC_MAX_RETRIES    = "MAX_RETRIES"
-	PROPNAME_SVC_RETRY_INTERVAL = "RETRY_INTERVAL"
+	PROPNAME_SVC_URL                       = "URL"
+	PROPNAME_SVC_DISABLE_SSL               = "DISABLE_SSL"
+	PROPNAME_SVC_ENABLE_GZIP               = "ENABLE_GZIP"
+	PROPNAME_SVC_COMPRESSION               = "COMPRESSION"
+	PROPNAME_SVC_COMPRESSION_LEVEL         = "COMPRESSION_LEVEL"
+	PROPNAME_SVC_COMPRESSION_MIN_BYTES     = "COMPRESSION_MIN_BYTES"
+	PROPNAME_SVC_ENABLE_DECOMPRESSION      = "ENABLE_DECOMPRESSION"
+	PROPNAME_SVC_ENABLE_RETRIES            = "ENABLE_RETRIES"
+	PROPNAME_SVC_MAX_RETRIES               = "MAX_RETRIES"
+	PROPNAME_SVC_RETRY_INTERVAL            = "RETRY_INTERVAL"
+	PROPNAME_SVC_RETRY_MIN_INTERVAL        = "RETRY_MIN_INTERVAL"
+	PROPNAME_SVC_RETRY_MAX_ELAPSED         = "RETRY_MAX_ELAPSED"
+	PROPNAME_SVC_RETRY_JITTER              = "RETRY_JITTER"
+	PROPNAME_SVC_RETRY_STATUS_CODES        = "RETRY_STATUS_CODES"
+	PROPNAME_SVC_RETRY_METHODS             = "RETRY_METHODS"
+	PROPNAME_SVC_ENABLE_CIRCUIT_BREAKER    = "ENABLE_CIRCUIT_BREAKER"
+	PROPNAME_SVC_CIRCUIT_BREAKER_THRESHOLD = "CIRCUIT_BREAKER_FAILURE_THRESHOLD"
+	PROPNAME_SVC_CIRCUIT_BREAKER_COOL_DOWN = "CIRCUIT_BREAKER_COOL_DOWN"
+	PROPNAME_SVC_RETRY_BUDGET_RATIO        = "RETRY_BUDGET_RATIO"
+	PROPNAME_SVC_RETRY_BUDGET_MAX_TOKENS   = "RETRY_BUDGET_MAX_TOKENS"
 
 	// Authenticator properties.
 	PROPNAME_AUTH_TYPE               = "AUTH_TYPE"
@@ -326,6 +326,7 @@ func (authenticator *ContainerAuthenticator) Validate() error {
 func (authenticator *ContainerAuthenticator) GetToken() (string, error) {
 	if authenticator.getTokenData() == nil || !authenticator.getTokenData().isTokenValid() {
 		GetLogger().Debug("Performing synchronous token fetch...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_CONTAINER, false)
 		// synchronously request the token
 		err := authenticator.synchronizedRequestToken()
 		if err != nil {
@@ -333,11 +334,13 @@ func (authenticator *ContainerAuthenticator) GetToken() (string, error) {
 		}
 	} else if authenticator.getTokenData().needsRefresh() {
 		GetLogger().Debug("Performing background asynchronous token fetch...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_CONTAINER, true)
 		// If refresh needed, kick off a go routine in the background to get a new token
 		//nolint: errcheck
 		go authenticator.invokeRequestTokenData()
 	} else {
 		GetLogger().Debug("Using cached access token...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_CONTAINER, true)
 	}
 
 	// return an error if the access token is not valid or was not fetched
@@ -385,6 +388,11 @@ func (authenticator *ContainerAuthenticator) invokeRequestTokenData() error {
 // RequestToken first retrieves a CR token value from the current compute resource, then uses
 // that to obtain a new IAM access token from the IAM token server.
 func (authenticator *ContainerAuthenticator) RequestToken() (*IamTokenServerResponse, error) {
+	return instrumentTokenRequest(AUTHTYPE_CONTAINER, authenticator.requestToken)
+}
+
+// requestToken is the implementation of RequestToken.
+func (authenticator *ContainerAuthenticator) requestToken() (*IamTokenServerResponse, error) {
 	var err error
 
 	// First, retrieve the CR token value for this compute resource.
@@ -451,7 +459,7 @@ func (authenticator *ContainerAuthenticator) RequestToken() (*IamTokenServerResp
 	}
 
 	GetLogger().Debug("Invoking IAM 'get token' operation: %s", builder.URL)
-	resp, err := authenticator.client().Do(req)
+	resp, err := recordingClient(authenticator.client(), fmt.Sprintf(harTokenRequestComment, AUTHTYPE_CONTAINER)).Do(req)
 	if err != nil {
 		return nil, authenticationErrorf(err, &DetailedResponse{}, "noop", getComponentInfo())
 	}
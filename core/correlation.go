@@ -0,0 +1,101 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	// DEFAULT_REQUEST_ID_HEADER is the name of the header that carries
+	// a request's ID, unless configured otherwise.
+	DEFAULT_REQUEST_ID_HEADER = "X-Request-ID"
+
+	// TRANSACTION_ID_HEADER is the name of the response header that carries the ID
+	// assigned to a request by IBM Cloud, which should be provided to IBM support
+	// when reporting a problem with the request.
+	TRANSACTION_ID_HEADER = "X-Global-Transaction-Id"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID returns a copy of "ctx" that carries "requestID", so that the requests
+// associated with the returned Context are sent with this request ID (e.g. to correlate
+// them with an incoming request being processed by the application).
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// GetRequestID returns the request ID carried by "ctx", or "" if it doesn't carry one.
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// NewRequestID returns a new, randomly-generated request ID.
+func NewRequestID() string {
+	return newUUID()
+}
+
+// requestIDHeader returns the name of the header that carries the ID
+// of the requests processed by the service.
+func (service *BaseService) requestIDHeader() string {
+	if service.Options.RequestIDHeader != "" {
+		return service.Options.RequestIDHeader
+	}
+	return DEFAULT_REQUEST_ID_HEADER
+}
+
+// requestID returns the request ID carried by "req", or "" if request IDs are disabled.
+func (service *BaseService) requestID(req *http.Request) string {
+	if service.Options.DisableRequestIDs {
+		return ""
+	}
+	return getRequestHeader(req, service.requestIDHeader())
+}
+
+// getRequestHeader returns the value of the "name" header of "req", or "" if it isn't set.
+func getRequestHeader(req *http.Request, name string) string {
+	if value := req.Header.Get(name); value != "" {
+		return value
+	}
+
+	// The header might have been added with a non-canonical name (see RequestBuilder.AddHeader).
+	if values := req.Header[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// addRequestID adds a request ID to "req", unless request IDs are disabled or the request
+// already carries one. The request ID is taken from the request's Context, if it carries one,
+// or generated. Since the request ID is added before the request is sent, it is re-used by
+// each retry attempt.
+func (service *BaseService) addRequestID(req *http.Request) {
+	if service.Options.DisableRequestIDs {
+		return
+	}
+	headerName := service.requestIDHeader()
+	requestID := service.requestID(req)
+	if requestID == "" {
+		requestID = GetRequestID(req.Context())
+		if requestID == "" {
+			requestID = NewRequestID()
+		}
+		req.Header.Set(headerName, requestID)
+	}
+	GetLogger().Debug("Request ID (%s): %s\n", headerName, requestID)
+}
@@ -0,0 +1,116 @@
+//go:build all || fast || basesvc
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDs(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	var requestIDs []string
+	var correlationIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIDs = append(requestIDs, r.Header.Get("X-Request-ID"))
+		correlationIDs = append(correlationIDs, r.Header.Get("X-Correlation-ID"))
+		w.Header().Set(TRANSACTION_ID_HEADER, "txn-123")
+		if len(requestIDs) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// A generated request ID is re-used by each retry attempt.
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	service.EnableRetries(1, 10*time.Millisecond)
+	detailedResponse, err := invokeTestRequest(t, service, NewRequestBuilder(GET), "/instances", nil)
+	assert.Nil(t, err)
+	assert.Len(t, requestIDs, 2)
+	assert.Len(t, requestIDs[0], 36)
+	assert.Equal(t, requestIDs[0], requestIDs[1])
+	assert.Equal(t, requestIDs[0], detailedResponse.RequestID)
+	assert.Equal(t, "txn-123", detailedResponse.TransactionID)
+
+	// The request ID may be taken from the Context or from the request's headers.
+	requestIDs = nil
+	service.DisableRetries()
+	ctx := WithRequestID(context.Background(), "abc")
+	detailedResponse, err = invokeTestRequest(t, service, NewRequestBuilder(GET).WithContext(ctx), "/instances", nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"abc"}, requestIDs)
+	assert.Equal(t, "abc", detailedResponse.RequestID)
+	builder := NewRequestBuilder(GET).WithContext(ctx).AddHeader("X-Request-ID", "xyz")
+	detailedResponse, err = invokeTestRequest(t, service, builder, "/instances", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "xyz", detailedResponse.RequestID)
+
+	// The request ID may be sent with another header.
+	requestIDs = nil
+	service.SetRequestIDHeader("X-Correlation-ID")
+	assert.Equal(t, "X-Correlation-ID", service.GetRequestIDHeader())
+	detailedResponse, err = invokeTestRequest(t, service, NewRequestBuilder(GET), "/instances", nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{""}, requestIDs)
+	assert.Len(t, correlationIDs[len(correlationIDs)-1], 36)
+	assert.Equal(t, correlationIDs[len(correlationIDs)-1], detailedResponse.RequestID)
+
+	// Request IDs may be disabled.
+	service.SetDisableRequestIDs(true)
+	assert.True(t, service.GetDisableRequestIDs())
+	detailedResponse, err = invokeTestRequest(t, service, NewRequestBuilder(GET), "/instances", nil)
+	assert.Nil(t, err)
+	assert.Empty(t, correlationIDs[len(correlationIDs)-1])
+	assert.Empty(t, detailedResponse.RequestID)
+	assert.Equal(t, "txn-123", detailedResponse.TransactionID)
+}
+
+func TestRequestIDProblems(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(TRANSACTION_ID_HEADER, "txn-123")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	ctx := WithRequestID(context.Background(), "abc")
+	_, err := invokeTestRequest(t, service, NewRequestBuilder(GET).WithContext(ctx), "/instances", nil)
+	assert.NotNil(t, err)
+	sdkProblem, ok := err.(*SDKProblem)
+	assert.True(t, ok)
+	assert.Contains(t, sdkProblem.GetConsoleMessage(), "request_id: abc\ntransaction_id: txn-123\n")
+	assert.Contains(t, sdkProblem.httpProblem.GetConsoleMessage(), "request_id: abc\ntransaction_id: txn-123\n")
+
+	// The IDs are kept when an SDK wraps the problem.
+	wrapped := SDKErrorf(err, "", "wrapped", NewProblemComponent("my-sdk", "1.0.0"))
+	assert.Contains(t, wrapped.GetConsoleMessage(), "request_id: abc\ntransaction_id: txn-123\n")
+
+	// Without a response, only the request ID is known.
+	server.Close()
+	_, err = invokeTestRequest(t, service, NewRequestBuilder(GET).WithContext(ctx), "/instances", nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.(*SDKProblem).GetConsoleMessage(), "request_id: abc\n")
+	assert.NotContains(t, err.(*SDKProblem).GetConsoleMessage(), "transaction_id")
+}
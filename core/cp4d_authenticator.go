@@ -26,6 +26,10 @@ import (
 	"time"
 )
 
+const (
+	cp4dAuthOperationPath = "/v1/authorize"
+)
+
 // CloudPakForDataAuthenticator uses either a username/password pair or a
 // username/apikey pair to obtain a suitable bearer token from the CP4D authentication service,
 // and adds the bearer token to requests via an Authorization header of the form:
@@ -233,6 +237,7 @@ func (authenticator *CloudPakForDataAuthenticator) setTokenData(tokenData *cp4dT
 func (authenticator *CloudPakForDataAuthenticator) GetToken() (string, error) {
 	if authenticator.getTokenData() == nil || !authenticator.getTokenData().isTokenValid() {
 		GetLogger().Debug("Performing synchronous token fetch...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_CP4D, false)
 		// synchronously request the token
 		err := authenticator.synchronizedRequestToken()
 		if err != nil {
@@ -240,11 +245,13 @@ func (authenticator *CloudPakForDataAuthenticator) GetToken() (string, error) {
 		}
 	} else if authenticator.getTokenData().needsRefresh() {
 		GetLogger().Debug("Performing background asynchronous token fetch...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_CP4D, true)
 		// If refresh needed, kick off a go routine in the background to get a new token
 		//nolint: errcheck
 		go authenticator.invokeRequestTokenData()
 	} else {
 		GetLogger().Debug("Using cached access token...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_CP4D, true)
 	}
 
 	// return an error if the access token is not valid or was not fetched
@@ -274,7 +281,7 @@ func (authenticator *CloudPakForDataAuthenticator) synchronizedRequestToken() er
 // unmarshals the token information to the tokenData cache. Returns
 // an error if the token was unable to be fetched, otherwise returns nil
 func (authenticator *CloudPakForDataAuthenticator) invokeRequestTokenData() error {
-	tokenResponse, err := authenticator.requestToken()
+	tokenResponse, err := instrumentTokenRequest(AUTHTYPE_CP4D, authenticator.requestToken)
 	if err != nil {
 		authenticator.setTokenData(nil)
 		return err
@@ -310,7 +317,7 @@ func (authenticator *CloudPakForDataAuthenticator) requestToken() (tokenResponse
 	}
 
 	builder := NewRequestBuilder(POST)
-	_, err = builder.ResolveRequestURL(authenticator.URL, "/v1/authorize", nil)
+	_, err = builder.ResolveRequestURL(authenticator.URL, cp4dAuthOperationPath, nil)
 	if err != nil {
 		return
 	}
@@ -347,7 +354,7 @@ func (authenticator *CloudPakForDataAuthenticator) requestToken() (tokenResponse
 	}
 
 	GetLogger().Debug("Invoking CP4D token service operation: %s", builder.URL)
-	resp, err := authenticator.client().Do(req)
+	resp, err := recordingClient(authenticator.client(), fmt.Sprintf(harTokenRequestComment, AUTHTYPE_CP4D)).Do(req)
 	if err != nil {
 		err = SDKErrorf(err, "", "cp4d-request-error", getComponentInfo())
 		return
@@ -0,0 +1,216 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// CurlOptions configures the curl commands returned by CurlCommand.
+type CurlOptions struct {
+	// RevealSecrets indicates whether or not secrets (e.g. the "Authorization" header)
+	// should be included in the curl command. By default, they are redacted with RedactSecrets.
+	RevealSecrets bool
+
+	// Insecure indicates whether or not the curl command should skip the verification
+	// of the server's SSL certificate ("-k"), e.g. because the SSL verification of the
+	// service that would send the request is disabled (see BaseService.IsSSLDisabled).
+	Insecure bool
+}
+
+// CurlCommand returns a curl command line that sends a request equivalent to "req"
+// (e.g. a request returned by RequestBuilder.Build), so that the request can be reproduced
+// outside of Go. The request's body is read, but "req" may still be sent afterwards.
+//
+// The parts of a "multipart/form-data" body are passed with the "-F" option. A part with a file name
+// refers to a local file with that name, which must be provided when the command is run.
+// A body that can't be passed on the command line as-is (e.g. a compressed body) is base64-encoded
+// and piped into curl.
+func CurlCommand(req *http.Request, options *CurlOptions) (string, error) {
+	if options == nil {
+		options = &CurlOptions{}
+	}
+	redact := RedactSecrets
+	if options.RevealSecrets {
+		redact = func(input string) string { return input }
+	}
+
+	body, err := readRequestBody(req)
+	if err != nil {
+		return "", RepurposeSDKProblem(err, "curl-read-body")
+	}
+
+	args := []string{"curl"}
+	if options.Insecure {
+		args = append(args, "-k")
+	}
+	if req.Method == http.MethodHead {
+		args = append(args, "--head")
+	} else if req.Method != http.MethodGet || body != nil {
+		args = append(args, "-X", shellQuote(req.Method))
+	}
+	args = append(args, shellQuote(redact(req.URL.String())))
+
+	// Work out how the body will be passed to curl.
+	var bodyArgs []string
+	var stdin []byte
+	skippedHeaders := map[string]bool{CONTENT_LENGTH: true}
+	mediaType, params, _ := mime.ParseMediaType(req.Header.Get(CONTENT_TYPE))
+	encoding := req.Header.Get(CONTENT_ENCODING)
+	if body != nil {
+		if encoding != "" {
+			stdin = curlCompressedBody(body, encoding, redact)
+		} else if formArgs, ok := curlFormArgs(body, mediaType, params["boundary"], redact); ok {
+			// curl will generate the "Content-Type" header, with its own boundary.
+			bodyArgs = formArgs
+			skippedHeaders[CONTENT_TYPE] = true
+		} else if text := redact(string(body)); utf8.ValidString(text) {
+			bodyArgs = []string{"--data-raw", shellQuote(text)}
+		} else {
+			stdin = []byte(text)
+		}
+		if stdin != nil {
+			bodyArgs = []string{"--data-binary", "@-"}
+		}
+	}
+
+	// Add the headers, in a predictable order.
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		if !skippedHeaders[http.CanonicalHeaderKey(name)] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	if req.Host != "" && req.Host != req.URL.Host && req.Header.Get("Host") == "" {
+		args = append(args, "-H", shellQuote("Host: "+req.Host))
+	}
+	for _, name := range names {
+		for _, value := range req.Header[name] {
+			if !options.RevealSecrets {
+				value = redactHeader(name, value)
+			}
+			if value == "" {
+				// This is how curl is told to send a header with no value.
+				args = append(args, "-H", shellQuote(name+";"))
+			} else {
+				args = append(args, "-H", shellQuote(name+": "+value))
+			}
+		}
+	}
+	if body != nil && bodyArgs != nil && !skippedHeaders[CONTENT_TYPE] && req.Header.Get(CONTENT_TYPE) == "" {
+		// Otherwise, curl would send the body as "application/x-www-form-urlencoded".
+		args = append(args, "-H", shellQuote(CONTENT_TYPE+":"))
+	}
+	args = append(args, bodyArgs...)
+
+	command := strings.Join(args, " ")
+	if stdin != nil {
+		command = "printf '%s' " + shellQuote(base64.StdEncoding.EncodeToString(stdin)) + " | base64 -d | " + command
+	}
+	return command, nil
+}
+
+// curlFormArgs returns the curl "-F" options that describe the parts of a "multipart/form-data" body,
+// or false if the body isn't a form or contains a part that can't be described that way.
+func curlFormArgs(body []byte, mediaType string, boundary string, redact func(string) string) ([]string, bool) {
+	if mediaType != "multipart/form-data" || boundary == "" {
+		return nil, false
+	}
+
+	var args []string
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			return args, true
+		}
+		if err != nil {
+			return nil, false
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, false
+		}
+
+		name := part.FormName()
+		var value string
+		if fileName := part.FileName(); fileName != "" {
+			value = "@" + curlFormQuote(fileName)
+		} else if utf8.Valid(content) {
+			// A part named after a secret (e.g. "apikey") is redacted as a whole.
+			text := redact(string(content))
+			if redact(name+"=x") != name+"=x" {
+				text = "[redacted]"
+			}
+			value = curlFormQuote(text)
+		} else {
+			return nil, false
+		}
+		if contentType := part.Header.Get(CONTENT_TYPE); contentType != "" {
+			value += ";type=" + contentType
+		}
+		args = append(args, "-F", shellQuote(name+"="+value))
+	}
+}
+
+// curlCompressedBody returns "body", compressed with "encoding", with its secrets redacted.
+// The body is returned as-is if there are no secrets to redact or it can't be decompressed.
+func curlCompressedBody(body []byte, encoding string, redact func(string) string) []byte {
+	reader, err := newDecompressionReader(encoding, bytes.NewReader(body))
+	if err != nil || reader == nil {
+		return body
+	}
+	uncompressed, err := io.ReadAll(reader)
+	if err != nil {
+		return body
+	}
+	redacted := redact(string(uncompressed))
+	if redacted == string(uncompressed) {
+		return body
+	}
+
+	codec, err := NewCompressionCodec(encoding, 0, 0)
+	if err != nil || IsNil(codec) {
+		return body
+	}
+	compressedReader, err := NewCompressionReader(codec, strings.NewReader(redacted))
+	if err != nil {
+		return body
+	}
+	compressed, err := io.ReadAll(compressedReader)
+	if err != nil {
+		return body
+	}
+	return compressed
+}
+
+// shellQuote returns "s" quoted for a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// curlFormQuote returns "s" quoted for the value of a curl "-F" option.
+func curlFormQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
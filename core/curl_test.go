@@ -0,0 +1,165 @@
+//go:build all || fast || basesvc
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurlCommand(t *testing.T) {
+	builder := NewRequestBuilder(GET)
+	_, _ = builder.ResolveRequestURL("https://cloud.ibm.com", "/v1/things", nil)
+	builder.AddQuery("limit", "10")
+	builder.AddQuery("apikey", "secret")
+	builder.AddHeader("Accept", "application/json")
+	builder.AddHeader("Authorization", "Bearer token")
+	builder.AddHeader("X-Empty", "")
+	req, _ := builder.Build()
+
+	command, err := CurlCommand(req, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, `curl 'https://cloud.ibm.com/v1/things?apikey=[redacted]&limit=10' `+
+		`-H 'Accept: application/json' -H 'Authorization: [redacted]' -H 'X-Empty;'`, command)
+
+	command, err = CurlCommand(req, &CurlOptions{RevealSecrets: true, Insecure: true})
+	assert.Nil(t, err)
+	assert.Equal(t, `curl -k 'https://cloud.ibm.com/v1/things?apikey=secret&limit=10' `+
+		`-H 'Accept: application/json' -H 'Authorization: Bearer token' -H 'X-Empty;'`, command)
+
+	req.Method = http.MethodHead
+	command, err = CurlCommand(req, nil)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(command, "curl --head 'https://cloud.ibm.com/v1/things?"))
+}
+
+func TestCurlCommandBody(t *testing.T) {
+	builder := NewRequestBuilder(POST)
+	_, _ = builder.ResolveRequestURL("https://cloud.ibm.com", "/v1/things", nil)
+	builder.AddHeader(CONTENT_TYPE, "application/json")
+	_, _ = builder.SetBodyContentJSON(map[string]interface{}{"name": "it's", "apikey": "secret"})
+	req, _ := builder.Build()
+
+	command, err := CurlCommand(req, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, `curl -X 'POST' 'https://cloud.ibm.com/v1/things' -H 'Content-Type: application/json' `+
+		`--data-raw '{"apikey":"[redacted]","name":"it'\''s"}`+"\n'", command)
+
+	// The request can still be sent.
+	body, err := io.ReadAll(req.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"apikey":"secret","name":"it's"}`+"\n", string(body))
+
+	// Without a "Content-Type" header, curl must be told not to add its own.
+	builder = NewRequestBuilder(PUT)
+	_, _ = builder.ResolveRequestURL("https://cloud.ibm.com", "/v1/things", nil)
+	_, _ = builder.SetBodyContentString("text")
+	req, _ = builder.Build()
+	command, err = CurlCommand(req, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, `curl -X 'PUT' 'https://cloud.ibm.com/v1/things' -H 'Content-Type:' --data-raw 'text'`, command)
+}
+
+func TestCurlCommandMultipart(t *testing.T) {
+	builder := NewRequestBuilder(POST)
+	_, _ = builder.ResolveRequestURL("https://cloud.ibm.com", "/v1/files", nil)
+	builder.AddFormData("metadata", "", "text/plain", `a "quoted" value`)
+	builder.AddFormData("file", "data.bin", "application/octet-stream", bytes.NewReader([]byte{0, 1, 2}))
+	builder.AddFormData("apikey", "", "", "secret")
+	req, _ := builder.Build()
+
+	command, err := CurlCommand(req, nil)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(command, `curl -X 'POST' 'https://cloud.ibm.com/v1/files' -F `))
+	assert.Contains(t, command, ` -F 'metadata="a \"quoted\" value";type=text/plain'`)
+	assert.Contains(t, command, ` -F 'file=@"data.bin";type=application/octet-stream'`)
+	assert.Contains(t, command, ` -F 'apikey="[redacted]"'`)
+	assert.NotContains(t, command, "multipart/form-data")
+}
+
+func TestCurlCommandGzip(t *testing.T) {
+	builder := NewRequestBuilder(POST)
+	builder.EnableGzipCompression = true
+	_, _ = builder.ResolveRequestURL("https://cloud.ibm.com", "/v1/things", nil)
+	builder.AddHeader(CONTENT_TYPE, "application/json")
+	_, _ = builder.SetBodyContentJSON(map[string]interface{}{"apikey": "secret"})
+	req, _ := builder.Build()
+
+	decodeBody := func(command string) string {
+		matches := regexp.MustCompile(`^printf '%s' '([^']*)' \| base64 -d \| curl -X 'POST' 'https://cloud.ibm.com/v1/things' ` +
+			`-H 'Content-Encoding: gzip' -H 'Content-Type: application/json' --data-binary @-$`).FindStringSubmatch(command)
+		if !assert.Len(t, matches, 2) {
+			return ""
+		}
+		compressed, err := base64.StdEncoding.DecodeString(matches[1])
+		assert.Nil(t, err)
+		reader, err := gzip.NewReader(bytes.NewReader(compressed))
+		assert.Nil(t, err)
+		body, err := io.ReadAll(reader)
+		assert.Nil(t, err)
+		return string(body)
+	}
+
+	command, err := CurlCommand(req, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"apikey":"[redacted]"}`+"\n", decodeBody(command))
+
+	command, err = CurlCommand(req, &CurlOptions{RevealSecrets: true})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"apikey":"secret"}`+"\n", decodeBody(command))
+}
+
+func TestLogCurlCommands(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	buf := new(bytes.Buffer)
+	defer SetLogger(GetLogger())
+	SetLogger(NewLogger(LevelDebug, log.New(buf, "", 0), log.New(buf, "", 0)))
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &BasicAuthenticator{Username: "user", Password: "password"},
+	})
+	assert.Nil(t, err)
+	service.SetLogCurlCommands(true)
+	assert.True(t, service.GetLogCurlCommands())
+	service.DisableSSLVerification()
+
+	builder := NewRequestBuilder(POST)
+	_, _ = builder.ResolveRequestURL(server.URL, "/things", nil)
+	_, _ = builder.SetBodyContentString("text")
+	req, _ := builder.Build()
+	_, err = service.Request(req, nil)
+	assert.Nil(t, err)
+
+	assert.Contains(t, buf.String(), "Request:\ncurl -k -X 'POST' '"+server.URL+"/things' -H 'Authorization: [redacted]' ")
+	assert.Contains(t, buf.String(), " --data-raw 'text'\n")
+	assert.NotContains(t, buf.String(), "POST /things HTTP/1.1")
+}
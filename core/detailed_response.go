@@ -57,6 +57,37 @@ type DetailedResponse struct {
 	// either for a successful or unsuccessful operation.
 	// 2) the operation was unsuccessful, and the response body contains a non-JSON response.
 	RawResult []byte `yaml:"raw_result,omitempty"`
+
+	// This field describes the original encoding and size of the response body if it was
+	// compressed by the server and then decompressed by the service (see ServiceOptions.EnableResponseDecompression).
+	Compression *ResponseCompression `yaml:"compression,omitempty"`
+
+	// This field will be true if the response was served from the service's ResponseCache,
+	// either because the cached response was fresh or because the server indicated
+	// (with a 304 status code) that it has not been modified.
+	FromCache bool `yaml:"from_cache,omitempty"`
+
+	// This field describes each attempt made to send the request, if automatic retries
+	// are enabled (see BaseService.EnableRetries). For example, it shows how long each attempt
+	// took, and how long the service waited before retrying it.
+	Attempts []RetryAttempt `yaml:"attempts,omitempty"`
+
+	// This field is the number of duplicate requests that were sent because no response
+	// had been received after the hedging delay (see HedgingPolicy).
+	Hedges int `yaml:"hedges,omitempty"`
+
+	// This field is the ID sent with the request (see ServiceOptions.DisableRequestIDs).
+	RequestID string `yaml:"request_id,omitempty"`
+
+	// This field is the ID assigned to the request by IBM Cloud, taken from
+	// the response's "X-Global-Transaction-Id" header. It should be provided
+	// to IBM support when reporting a problem with the request.
+	TransactionID string `yaml:"transaction_id,omitempty"`
+
+	// This field describes the request that would have been sent, if the service
+	// is in dry-run mode (see ServiceOptions.DryRun), in which case the fields that
+	// describe the response (e.g. StatusCode) are not set.
+	Request *CapturedRequest `yaml:"request,omitempty"`
 }
 
 // GetHeaders returns the headers
@@ -0,0 +1,132 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	ERRORMSG_READ_REQUEST_BODY = "An error occurred while reading the request body: %s"
+)
+
+// DryRunOptions configures the "dry-run" mode of a service (see ServiceOptions.DryRun).
+// In dry-run mode, each request is prepared exactly as it would be sent (with the default
+// headers, User-Agent header, authentication, compression, etc.), but instead of being sent,
+// the request is captured and described by the DetailedResponse.Request field.
+type DryRunOptions struct {
+	// SkipAuthentication indicates whether or not the service's authenticator
+	// should be skipped, so that the captured requests carry no authentication.
+	SkipAuthentication bool
+
+	// PlaceholderToken is an optional token to be used instead of invoking the service's
+	// authenticator (which might need to obtain a token from a token server).
+	// If specified, the captured requests carry an "Authorization: Bearer <PlaceholderToken>" header.
+	PlaceholderToken string
+}
+
+// CapturedRequest describes a request that was prepared but not sent
+// by a service in dry-run mode (see ServiceOptions.DryRun).
+// Secrets (e.g. the "Authorization" header) are redacted with RedactSecrets.
+type CapturedRequest struct {
+	// The HTTP method of the request (e.g. "GET").
+	Method string `yaml:"method"`
+
+	// The URL of the request.
+	URL string `yaml:"url"`
+
+	// The HTTP headers of the request.
+	Headers http.Header `yaml:"headers"`
+
+	// The body of the request, if any. If the body is compressed (i.e. the request has
+	// a "Content-Encoding" header), it contains the compressed bytes and secrets are not redacted.
+	Body []byte `yaml:"body,omitempty"`
+}
+
+// authenticateDryRun adds the authentication described by the service's DryRunOptions to "req".
+// It returns false if the service's authenticator should be invoked instead.
+func (service *BaseService) authenticateDryRun(req *http.Request) bool {
+	options := service.Options.DryRun
+	if options == nil {
+		return false
+	}
+	if options.PlaceholderToken != "" {
+		req.Header.Set("Authorization", "Bearer "+options.PlaceholderToken)
+		return true
+	}
+	return options.SkipAuthentication
+}
+
+// captureRequest returns a description of "req", with its secrets redacted.
+// The request's body is read (and replaced, so that "req" may still be sent).
+func captureRequest(req *http.Request) (captured *CapturedRequest, err error) {
+	captured = &CapturedRequest{
+		Method:  req.Method,
+		URL:     RedactSecrets(req.URL.String()),
+		Headers: make(http.Header, len(req.Header)),
+	}
+	for name, values := range req.Header {
+		for _, value := range values {
+			captured.Headers[name] = append(captured.Headers[name], redactHeader(name, value))
+		}
+	}
+	if req.Host != "" && req.Host != req.URL.Host {
+		captured.Headers.Set("Host", req.Host)
+	}
+
+	body, err := readRequestBody(req)
+	if err != nil {
+		err = RepurposeSDKProblem(err, "dry-run-read-body")
+		return
+	}
+	if body != nil && req.Header.Get(CONTENT_ENCODING) == "" {
+		body = []byte(RedactSecrets(string(body)))
+	}
+	captured.Body = body
+	return
+}
+
+// readRequestBody returns the body of "req" (nil if it has no body). Unless the body
+// can be obtained again with req.GetBody, it is replaced so that "req" may still be sent.
+func readRequestBody(req *http.Request) (body []byte, err error) {
+	if IsNil(req.Body) || req.Body == http.NoBody {
+		return
+	}
+
+	reader := req.Body
+	if req.GetBody != nil {
+		reader, err = req.GetBody()
+		if err != nil {
+			err = SDKErrorf(err, fmt.Sprintf(ERRORMSG_READ_REQUEST_BODY, err.Error()), "get-body-error", getComponentInfo())
+			return
+		}
+	}
+	body, err = io.ReadAll(reader)
+	_ = reader.Close()
+	if err != nil {
+		err = SDKErrorf(err, fmt.Sprintf(ERRORMSG_READ_REQUEST_BODY, err.Error()), "read-body-error", getComponentInfo())
+		return
+	}
+	if req.GetBody == nil {
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if len(body) == 0 {
+		body = nil
+	}
+	return
+}
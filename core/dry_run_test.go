@@ -0,0 +1,114 @@
+//go:build all || fast || basesvc
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDryRun(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The IAM authenticator would fail to obtain a token, if it were invoked.
+	authenticator, err := NewIamAuthenticatorBuilder().SetApiKey("apikey").SetURL(server.URL + "/missing").Build()
+	assert.Nil(t, err)
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: authenticator,
+		DryRun:        &DryRunOptions{PlaceholderToken: "placeholder"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "placeholder", service.GetDryRun().PlaceholderToken)
+	service.SetDefaultHeaders(http.Header{"X-Default": {"default"}})
+
+	build := func() *http.Request {
+		builder := NewRequestBuilder(POST)
+		_, _ = builder.ResolveRequestURL(server.URL, "/instances", nil)
+		builder.AddQuery("apikey", "secret")
+		builder.AddHeader("Accept", "application/json")
+		_, _ = builder.SetBodyContentJSON(map[string]interface{}{"name": "my-instance", "apikey": "secret"})
+		req, _ := builder.Build()
+		return req
+	}
+
+	var result map[string]interface{}
+	detailedResponse, err := service.Request(build(), &result)
+	assert.Nil(t, err)
+	assert.Zero(t, requests)
+	assert.Nil(t, result)
+	assert.Zero(t, detailedResponse.StatusCode)
+	assert.NotEmpty(t, detailedResponse.RequestID)
+
+	captured := detailedResponse.Request
+	assert.NotNil(t, captured)
+	assert.Equal(t, POST, captured.Method)
+	assert.Equal(t, server.URL+"/instances?apikey=[redacted]", captured.URL)
+	assert.Equal(t, "[redacted]", captured.Headers.Get("Authorization"))
+	assert.Equal(t, "default", captured.Headers.Get("X-Default"))
+	assert.Equal(t, service.UserAgent, captured.Headers.Get(headerNameUserAgent))
+	assert.Equal(t, detailedResponse.RequestID, captured.Headers.Get(DEFAULT_REQUEST_ID_HEADER))
+	assert.Equal(t, `{"apikey":"[redacted]","name":"my-instance"}`, strings.TrimSpace(string(captured.Body)))
+
+	// The authentication step may be skipped.
+	service.SetDryRun(&DryRunOptions{SkipAuthentication: true})
+	detailedResponse, err = service.Request(build(), nil)
+	assert.Nil(t, err)
+	assert.Empty(t, detailedResponse.Request.Headers.Values("Authorization"))
+
+	// Otherwise, the service's authenticator is invoked.
+	service.Options.Authenticator = &BasicAuthenticator{Username: "user", Password: "password"}
+	service.SetDryRun(&DryRunOptions{})
+	detailedResponse, err = service.Request(build(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "[redacted]", detailedResponse.Request.Headers.Get("Authorization"))
+
+	// A compressed body is captured as it would be sent.
+	codec, err := NewGzipCodec(0, 0)
+	assert.Nil(t, err)
+	service.SetCompression(codec)
+	detailedResponse, err = service.Request(build(), nil)
+	assert.Nil(t, err)
+	captured = detailedResponse.Request
+	assert.Equal(t, "gzip", captured.Headers.Get(CONTENT_ENCODING))
+	reader, err := gzip.NewReader(strings.NewReader(string(captured.Body)))
+	assert.Nil(t, err)
+	body, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"apikey":"secret","name":"my-instance"}`, strings.TrimSpace(string(body)))
+	assert.Zero(t, requests)
+
+	// Once dry-run mode is disabled, the requests are sent.
+	service.SetDryRun(nil)
+	detailedResponse, err = service.Request(build(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, http.StatusOK, detailedResponse.StatusCode)
+	assert.Nil(t, detailedResponse.Request)
+}
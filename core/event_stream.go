@@ -0,0 +1,318 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// TEXT_EVENT_STREAM is the mime type of a Server-Sent Events stream.
+	TEXT_EVENT_STREAM = "text/event-stream"
+
+	headerNameLastEventID = "Last-Event-ID"
+
+	// The default time to wait before reconnecting to an event stream,
+	// unless specified otherwise by the server via the "retry" field.
+	defaultEventStreamRetry = 3 * time.Second
+
+	ERRORMSG_NOT_EVENT_STREAM      = "The response is not an event stream, Content-Type=%s"
+	ERRORMSG_EVENT_STREAM_READ     = "An error occurred while reading the event stream: %s"
+	ERRORMSG_EVENT_STREAM_NO_RETRY = "The request body cannot be re-sent in order to reconnect to the event stream"
+)
+
+// ServerSentEvent is an event received within a Server-Sent Events ("text/event-stream") response.
+type ServerSentEvent struct {
+	// ID is the event's "id" field, or the ID of the most recent event that specified one.
+	ID string
+
+	// Event is the event's "event" field (the event type), or "message" if not specified.
+	Event string
+
+	// Data contains the event's "data" field(s). Multiple data fields are joined with "\n".
+	Data string
+
+	// Retry is the reconnection time specified by the event's "retry" field, or 0 if not specified.
+	Retry time.Duration
+}
+
+// UnmarshalData unmarshals the event's Data as JSON into "result".
+// If "unmarshaller" is specified, it is used to unmarshal the data as an instance of a model,
+// and "result" should be a pointer to a pointer to the model (e.g. **Foo).
+// Otherwise, the data is unmarshalled directly into "result" using the encoding/json package.
+func (event *ServerSentEvent) UnmarshalData(result interface{}, unmarshaller ModelUnmarshaller) (err error) {
+	if unmarshaller == nil {
+		err = json.Unmarshal([]byte(event.Data), result)
+	} else {
+		var rawMap map[string]json.RawMessage
+		err = json.Unmarshal([]byte(event.Data), &rawMap)
+		if err == nil {
+			err = UnmarshalModel(rawMap, "", result, unmarshaller)
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf(ERRORMSG_UNMARSHAL_RESPONSE_BODY, err.Error())
+		err = SDKErrorf(err, "", "event-data-unmarshal-error", getComponentInfo())
+	}
+	return
+}
+
+// EventStream reads the events from a Server-Sent Events response.
+// It is returned by BaseService.RequestStream().
+type EventStream struct {
+	service *BaseService
+	req     *http.Request
+
+	body   io.ReadCloser
+	reader *bufio.Reader
+
+	lastEventID   string
+	idBuffer      string
+	retry         time.Duration
+	maxReconnects int
+	reconnects    int
+	closed        bool
+}
+
+// RequestStream invokes the specified HTTP request and returns an EventStream that can be used
+// to read the Server-Sent Events contained in the response.
+// The request is processed like BaseService.Request() (including authentication and the
+// handling of error responses), and the "Accept" header is set to "text/event-stream"
+// if not already present. The Result field of the returned DetailedResponse will contain
+// the EventStream.
+//
+// The caller is responsible for closing the EventStream.
+func (service *BaseService) RequestStream(req *http.Request) (stream *EventStream, detailedResponse *DetailedResponse, err error) {
+	if req.Header.Get(Accept) == "" {
+		req.Header.Set(Accept, TEXT_EVENT_STREAM)
+	}
+
+	stream = &EventStream{
+		service: service,
+		req:     req,
+		retry:   defaultEventStreamRetry,
+	}
+
+	detailedResponse, err = stream.connect(req)
+	if err != nil {
+		err = RepurposeSDKProblem(err, "")
+		return nil, detailedResponse, err
+	}
+	detailedResponse.Result = stream
+	return
+}
+
+// WithReconnect configures the stream to reconnect (up to "maxReconnects" times) if the
+// connection is lost while reading events. Each reconnection re-sends the original request
+// with the "Last-Event-ID" header set to the ID of the last event received, after waiting
+// for the reconnection time specified by the server (3 seconds by default).
+// A stream that ends normally is not reconnected.
+func (stream *EventStream) WithReconnect(maxReconnects int) *EventStream {
+	stream.maxReconnects = maxReconnects
+	return stream
+}
+
+// LastEventID returns the ID of the most recent event that specified one.
+func (stream *EventStream) LastEventID() string {
+	return stream.lastEventID
+}
+
+// Next reads and returns the next event from the stream.
+// When the stream has ended, io.EOF is returned.
+func (stream *EventStream) Next() (*ServerSentEvent, error) {
+	for {
+		if stream.closed {
+			return nil, io.EOF
+		}
+
+		event, err := stream.readEvent()
+		if err == nil || err == io.EOF {
+			return event, err
+		}
+
+		// The connection was lost, so try to reconnect if possible.
+		if stream.reconnects >= stream.maxReconnects || stream.req.Context().Err() != nil {
+			err = fmt.Errorf(ERRORMSG_EVENT_STREAM_READ, err.Error())
+			return nil, SDKErrorf(err, "", "event-stream-read-error", getComponentInfo())
+		}
+		if err = stream.reconnect(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Events returns an iterator over the remaining events in the stream.
+// If an error occurs while reading an event, the error is yielded along with a nil
+// event and the iteration stops.
+// The stream is closed when the iteration completes or is stopped by the caller.
+func (stream *EventStream) Events() iter.Seq2[*ServerSentEvent, error] {
+	return func(yield func(*ServerSentEvent, error) bool) {
+		defer stream.Close() // #nosec G307
+		for {
+			event, err := stream.Next()
+			if err == io.EOF {
+				return
+			}
+			if !yield(event, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Close closes the event stream.
+func (stream *EventStream) Close() error {
+	stream.closed = true
+	if stream.body != nil {
+		return stream.body.Close()
+	}
+	return nil
+}
+
+// connect sends "req" and prepares the stream to read events from the response.
+func (stream *EventStream) connect(req *http.Request) (detailedResponse *DetailedResponse, err error) {
+	return stream.service.invoke(req, func(httpResponse *http.Response) (*DetailedResponse, error) {
+		detailedResponse, contentType := getDetailedResponseAndContentType(httpResponse)
+
+		// A 204 response indicates the server has no events for us.
+		if httpResponse.StatusCode == http.StatusNoContent {
+			if !IsNil(httpResponse.Body) {
+				_ = httpResponse.Body.Close()
+			}
+			stream.closed = true
+			return detailedResponse, nil
+		}
+
+		if !strings.HasPrefix(strings.ToLower(contentType), TEXT_EVENT_STREAM) {
+			_ = httpResponse.Body.Close()
+			err := fmt.Errorf(ERRORMSG_NOT_EVENT_STREAM, contentType)
+			return detailedResponse, SDKErrorf(err, "", "not-event-stream", getComponentInfo())
+		}
+
+		stream.body = httpResponse.Body
+		stream.reader = bufio.NewReader(httpResponse.Body)
+		return detailedResponse, nil
+	})
+}
+
+// reconnect waits for the reconnection time and then re-sends the original request
+// along with the "Last-Event-ID" header.
+func (stream *EventStream) reconnect() error {
+	stream.reconnects++
+	if stream.body != nil {
+		_ = stream.body.Close()
+	}
+	stream.idBuffer = stream.lastEventID
+
+	req := stream.req.Clone(stream.req.Context())
+	if stream.req.Body != nil && stream.req.Body != http.NoBody {
+		if stream.req.GetBody == nil {
+			return SDKErrorf(errors.New(ERRORMSG_EVENT_STREAM_NO_RETRY), "", "no-get-body", getComponentInfo())
+		}
+		body, err := stream.req.GetBody()
+		if err != nil {
+			return SDKErrorf(err, "", "get-body-error", getComponentInfo())
+		}
+		req.Body = body
+	}
+	if stream.lastEventID != "" {
+		req.Header.Set(headerNameLastEventID, stream.lastEventID)
+	}
+
+	GetLogger().Debug("Reconnecting to event stream in %s (attempt %d of %d)", stream.retry.String(), stream.reconnects, stream.maxReconnects)
+	timer := time.NewTimer(stream.retry)
+	select {
+	case <-req.Context().Done():
+		timer.Stop()
+		return SDKErrorf(req.Context().Err(), "", "reconnect-context-done", getComponentInfo())
+	case <-timer.C:
+	}
+
+	_, err := stream.connect(req)
+	return RepurposeSDKProblem(err, "reconnect-error")
+}
+
+// readEvent reads lines from the stream until a complete event has been received,
+// as described in the "Event stream interpretation" section of the HTML specification.
+func (stream *EventStream) readEvent() (*ServerSentEvent, error) {
+	if stream.reader == nil {
+		return nil, io.EOF
+	}
+
+	event := &ServerSentEvent{}
+	var data []string
+	hasData := false
+
+	for {
+		line, err := stream.reader.ReadString('\n')
+		if err != nil {
+			// An incomplete event at the end of the stream is discarded.
+			if err == io.EOF && line != "" {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		// A blank line dispatches the event (if it contains any data).
+		if line == "" {
+			if !hasData {
+				event = &ServerSentEvent{}
+				continue
+			}
+			stream.lastEventID = stream.idBuffer
+			event.Data = strings.Join(data, "\n")
+			event.ID = stream.lastEventID
+			if event.Event == "" {
+				event.Event = "message"
+			}
+			return event, nil
+		}
+
+		// Lines beginning with a colon are comments.
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			data = append(data, value)
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				stream.idBuffer = value
+			}
+		case "retry":
+			if millis, err := strconv.ParseUint(value, 10, 32); err == nil {
+				event.Retry = time.Duration(millis) * time.Millisecond
+				stream.retry = event.Retry
+			}
+		}
+	}
+}
@@ -0,0 +1,231 @@
+//go:build all || fast || basesvc
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newGenerateRequestBuilder returns a builder for the streaming operation invoked by the tests.
+func newGenerateRequestBuilder() *RequestBuilder {
+	builder := NewRequestBuilder(POST)
+	_, _ = builder.SetBodyContentJSON(map[string]string{"input": "hello"})
+	return builder
+}
+
+func TestRequestStream(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, TEXT_EVENT_STREAM, r.Header.Get(Accept))
+		w.Header().Set(CONTENT_TYPE, "text/event-stream; charset=utf-8")
+		fmt.Fprint(w, ": this is a comment\n\n")
+		fmt.Fprint(w, "id: 1\ndata: {\"name\": \"a\"}\n\n")
+		fmt.Fprint(w, "event: progress\r\ndata: line 1\r\ndata: line 2\r\nretry: 2500\r\n\r\n")
+		fmt.Fprint(w, "id\ndata\n\n")
+		fmt.Fprint(w, "data: incomplete")
+	}))
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	stream, detailedResponse, err := service.RequestStream(buildTestRequest(t, service, newGenerateRequestBuilder(), "/generate"))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, detailedResponse.StatusCode)
+	assert.Equal(t, stream, detailedResponse.Result)
+
+	event, err := stream.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, &ServerSentEvent{ID: "1", Event: "message", Data: `{"name": "a"}`}, event)
+
+	var foo *Foo
+	assert.Nil(t, event.UnmarshalData(&foo, unmarshalFoo))
+	assert.Equal(t, "a", *foo.Name)
+
+	event, err = stream.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, &ServerSentEvent{ID: "1", Event: "progress", Data: "line 1\nline 2", Retry: 2500 * time.Millisecond}, event)
+
+	event, err = stream.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, &ServerSentEvent{ID: "", Event: "message", Data: ""}, event)
+	assert.Equal(t, "", stream.LastEventID())
+
+	// The incomplete event at the end of the stream is reported as an error since reconnect is disabled.
+	_, err = stream.Next()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), io.ErrUnexpectedEOF.Error())
+	assert.Nil(t, stream.Close())
+}
+
+func TestRequestStreamEvents(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(CONTENT_TYPE, TEXT_EVENT_STREAM)
+		for i := 0; i < 5; i++ {
+			fmt.Fprintf(w, "id: %d\ndata: {\"name\": \"item-%d\"}\n\n", i, i)
+		}
+	}))
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	stream, _, err := service.RequestStream(buildTestRequest(t, service, newGenerateRequestBuilder(), "/generate"))
+	assert.Nil(t, err)
+
+	var names []string
+	for event, err := range stream.Events() {
+		assert.Nil(t, err)
+		var result map[string]string
+		assert.Nil(t, event.UnmarshalData(&result, nil))
+		names = append(names, result["name"])
+	}
+	assert.Equal(t, []string{"item-0", "item-1", "item-2", "item-3", "item-4"}, names)
+	assert.Equal(t, "4", stream.LastEventID())
+
+	_, err = stream.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestRequestStreamReconnect(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	var lastEventIDs []string
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+
+		w.Header().Set(CONTENT_TYPE, TEXT_EVENT_STREAM)
+		if len(lastEventIDs) == 1 {
+			// Simulate a lost connection in the middle of the second event.
+			fmt.Fprint(w, "retry: 10\nid: 1\ndata: first\n\nid: 2\ndata: sec")
+			return
+		}
+		fmt.Fprint(w, "id: 2\ndata: second\n\nid: 3\ndata: third\n\n")
+	}))
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	stream, _, err := service.RequestStream(buildTestRequest(t, service, newGenerateRequestBuilder(), "/generate"))
+	assert.Nil(t, err)
+
+	var data []string
+	for event, err := range stream.WithReconnect(1).Events() {
+		assert.Nil(t, err)
+		data = append(data, event.Data)
+	}
+	assert.Equal(t, []string{"first", "second", "third"}, data)
+	assert.Equal(t, []string{"", "1"}, lastEventIDs)
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+func TestRequestStreamReconnectError(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > 1 {
+			w.Header().Set(CONTENT_TYPE, APPLICATION_JSON)
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error": "stream not found"}`)
+			return
+		}
+		w.Header().Set(CONTENT_TYPE, TEXT_EVENT_STREAM)
+		fmt.Fprint(w, "retry: 1\nid: 1\ndata: first\n\ndata: trunc")
+	}))
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	stream, _, err := service.RequestStream(buildTestRequest(t, service, newGenerateRequestBuilder(), "/generate"))
+	assert.Nil(t, err)
+	stream.WithReconnect(3)
+
+	event, err := stream.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "first", event.Data)
+
+	_, err = stream.Next()
+	assert.NotNil(t, err)
+	assert.Equal(t, "stream not found", err.Error())
+	assert.Equal(t, 2, requests)
+}
+
+func TestRequestStreamErrorResponse(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(CONTENT_TYPE, APPLICATION_JSON)
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errors": [{"message": "invalid input"}]}`)
+	}))
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	stream, detailedResponse, err := service.RequestStream(buildTestRequest(t, service, newGenerateRequestBuilder(), "/generate"))
+	assert.Nil(t, stream)
+	assert.NotNil(t, err)
+	assert.Equal(t, "invalid input", err.Error())
+	assert.Equal(t, http.StatusBadRequest, detailedResponse.StatusCode)
+
+	sdkProblem, ok := err.(*SDKProblem)
+	assert.True(t, ok)
+	assert.NotNil(t, sdkProblem.httpProblem)
+	assert.Equal(t, http.StatusBadRequest, sdkProblem.httpProblem.Response.GetStatusCode())
+}
+
+func TestRequestStreamNotEventStream(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(CONTENT_TYPE, APPLICATION_JSON)
+		fmt.Fprint(w, `{"name": "a"}`)
+	}))
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	stream, _, err := service.RequestStream(buildTestRequest(t, service, newGenerateRequestBuilder(), "/generate"))
+	assert.Nil(t, stream)
+	assert.NotNil(t, err)
+	assert.Equal(t, "The response is not an event stream, Content-Type=application/json", err.Error())
+}
+
+func TestRequestStreamNoContent(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	stream, detailedResponse, err := service.RequestStream(buildTestRequest(t, service, newGenerateRequestBuilder(), "/generate"))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusNoContent, detailedResponse.StatusCode)
+
+	_, err = stream.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestServerSentEventUnmarshalDataError(t *testing.T) {
+	event := &ServerSentEvent{Data: "not json"}
+	var foo *Foo
+	err := event.UnmarshalData(&foo, unmarshalFoo)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "An error occurred while processing the HTTP response")
+}
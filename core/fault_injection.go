@@ -0,0 +1,356 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// errFaultConnectionReset is the error of a FaultConnectionReset.
+var errFaultConnectionReset = errors.New("connection reset by peer")
+
+// FaultKind is the kind of a Fault injected by a FaultInjector.
+type FaultKind int
+
+const (
+	// FaultLatency delays the request by the fault's Delay before it is sent.
+	FaultLatency FaultKind = iota
+
+	// FaultConnectionReset fails the request with a "connection reset by peer" error, without sending it.
+	FaultConnectionReset
+
+	// FaultTLSHandshakeError fails the request with a TLS handshake failure, without sending it.
+	// The SDK's default retry policy retries the request.
+	FaultTLSHandshakeError
+
+	// FaultTLSCertificateError fails the request with a TLS certificate verification error
+	// (i.e. an untrusted certificate), without sending it.
+	// The SDK's default retry policy doesn't retry the request.
+	FaultTLSCertificateError
+
+	// FaultTooManyRequests responds to the request with a 429 status code and,
+	// if the fault's RetryAfter is set, a "Retry-After" header, without sending it.
+	FaultTooManyRequests
+
+	// FaultServerError responds to the request and the next Burst-1 requests with the fault's StatusCode
+	// (503, by default), without sending them.
+	FaultServerError
+
+	// FaultTruncatedBody sends the request, but the response's body fails with io.ErrUnexpectedEOF
+	// after BodyBytes bytes (as if the connection were closed).
+	FaultTruncatedBody
+
+	// FaultSlowBody sends the request, but the response's body is delivered in chunks of BodyBytes bytes
+	// (1, by default), after a Delay before each chunk.
+	FaultSlowBody
+)
+
+var faultKindNames = map[FaultKind]string{
+	FaultLatency:             "latency",
+	FaultConnectionReset:     "connection-reset",
+	FaultTLSHandshakeError:   "tls-handshake-error",
+	FaultTLSCertificateError: "tls-certificate-error",
+	FaultTooManyRequests:     "too-many-requests",
+	FaultServerError:         "server-error",
+	FaultTruncatedBody:       "truncated-body",
+	FaultSlowBody:            "slow-body",
+}
+
+func (kind FaultKind) String() string {
+	if name, ok := faultKindNames[kind]; ok {
+		return name
+	}
+	return fmt.Sprintf("fault-%d", int(kind))
+}
+
+// Fault describes a fault injected into a request by a FaultInjector.
+// The fields that apply depend on the kind of the fault (see FaultKind).
+type Fault struct {
+	Kind FaultKind
+
+	// Delay is the latency of a FaultLatency, or the delay before each chunk of a FaultSlowBody.
+	Delay time.Duration
+
+	// RetryAfter is the value (rounded up to seconds) of the "Retry-After" header of a FaultTooManyRequests.
+	RetryAfter time.Duration
+
+	// StatusCode is the status code of a FaultServerError (503, by default).
+	StatusCode int
+
+	// Burst is the number of consecutive requests that fail with a FaultServerError (1, by default).
+	Burst int
+
+	// BodyBytes is the number of bytes of the body delivered before a FaultTruncatedBody,
+	// or the size of each chunk of a FaultSlowBody (1, by default).
+	BodyBytes int
+}
+
+// FaultInjector injects faults into the requests sent by an http.Client, e.g. to exercise
+// the retry policy (see IBMCloudSDKRetryPolicy and IBMCloudSDKBackoffPolicy) and the timeouts
+// of a service. Faults are injected by script (see FaultInjector.Script) or at random
+// (see FaultInjector.AddFault).
+//
+// A FaultInjector is installed in a service by replacing its http.Client with one returned by
+// FaultInjector.Client, so that each attempt to send a request (including retries) may be faulted:
+//
+//	service.SetHTTPClient(injector.Client(service.GetHTTPClient()))
+type FaultInjector struct {
+	mutex    sync.Mutex
+	script   []*Fault
+	faults   []randomFault
+	random   *rand.Rand
+	injected map[FaultKind]int
+}
+
+type randomFault struct {
+	probability float64
+	fault       *Fault
+}
+
+// NewFaultInjector returns a new FaultInjector that doesn't inject any faults yet.
+func NewFaultInjector() *FaultInjector {
+	return &FaultInjector{injected: map[FaultKind]int{}}
+}
+
+// Script specifies the faults injected into the next requests, in order, before any random faults.
+// A nil fault leaves the corresponding request alone.
+func (injector *FaultInjector) Script(faults ...*Fault) {
+	injector.mutex.Lock()
+	defer injector.mutex.Unlock()
+	injector.script = append(injector.script, faults...)
+}
+
+// AddFault injects "fault" into each request with the specified probability (between 0 and 1).
+// If several faults are added, at most one of them is injected into a request (the first one drawn).
+func (injector *FaultInjector) AddFault(probability float64, fault *Fault) {
+	injector.mutex.Lock()
+	defer injector.mutex.Unlock()
+	injector.faults = append(injector.faults, randomFault{probability: probability, fault: fault})
+}
+
+// SetSeed seeds the random choice of faults, so that it's reproducible.
+func (injector *FaultInjector) SetSeed(seed uint64) {
+	injector.mutex.Lock()
+	defer injector.mutex.Unlock()
+	injector.random = rand.New(rand.NewPCG(seed, seed)) // #nosec G404
+}
+
+// Injected returns the number of requests into which a fault of the specified kind was injected.
+func (injector *FaultInjector) Injected(kind FaultKind) int {
+	injector.mutex.Lock()
+	defer injector.mutex.Unlock()
+	return injector.injected[kind]
+}
+
+// Client returns a copy of "client" that sends its requests by way of the injector.
+// If "client" is a retryable client, the faults are injected into each attempt.
+func (injector *FaultInjector) Client(client *http.Client) *http.Client {
+	return clientWithTransport(client, injector.Transport)
+}
+
+// Transport returns an http.RoundTripper that injects faults into the requests sent by "transport".
+func (injector *FaultInjector) Transport(transport http.RoundTripper) http.RoundTripper {
+	return &faultTransport{injector: injector, transport: transport}
+}
+
+// nextFault returns the fault to be injected into the next request, or nil.
+func (injector *FaultInjector) nextFault() *Fault {
+	injector.mutex.Lock()
+	defer injector.mutex.Unlock()
+
+	var fault *Fault
+	if len(injector.script) > 0 {
+		fault = injector.script[0]
+		injector.script = injector.script[1:]
+	} else {
+		for _, candidate := range injector.faults {
+			if injector.float64() < candidate.probability {
+				fault = candidate.fault
+				break
+			}
+		}
+	}
+	if fault == nil {
+		return nil
+	}
+
+	// The rest of a burst of server errors is scripted.
+	if fault.Kind == FaultServerError && fault.Burst > 1 {
+		burst := make([]*Fault, fault.Burst-1)
+		for i := range burst {
+			burst[i] = &Fault{Kind: FaultServerError, StatusCode: fault.StatusCode}
+		}
+		injector.script = append(burst, injector.script...)
+	}
+	injector.injected[fault.Kind]++
+	return fault
+}
+
+func (injector *FaultInjector) float64() float64 {
+	if injector.random != nil {
+		return injector.random.Float64()
+	}
+	return rand.Float64() // #nosec G404
+}
+
+type faultTransport struct {
+	injector  *FaultInjector
+	transport http.RoundTripper
+}
+
+func (transport *faultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fault := transport.injector.nextFault()
+	if fault == nil {
+		return transport.transport.RoundTrip(req)
+	}
+	GetLogger().Debug("Injecting fault '%s' into request: %s %s\n", fault.Kind, req.Method, req.URL.Redacted())
+
+	// Unless it is sent, the request's body must be closed.
+	closeBody := func() {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+	}
+
+	switch fault.Kind {
+	case FaultLatency:
+		if err := sleepWithContext(req.Context(), fault.Delay); err != nil {
+			closeBody()
+			return nil, err
+		}
+
+	case FaultConnectionReset:
+		closeBody()
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", errFaultConnectionReset)}
+
+	case FaultTLSHandshakeError:
+		closeBody()
+		return nil, &net.OpError{Op: "remote error", Err: tls.AlertError(40)}
+
+	case FaultTLSCertificateError:
+		closeBody()
+		return nil, &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}
+
+	case FaultTooManyRequests:
+		closeBody()
+		resp := faultResponse(req, http.StatusTooManyRequests)
+		if fault.RetryAfter > 0 {
+			seconds := int64((fault.RetryAfter + time.Second - 1) / time.Second)
+			resp.Header.Set("Retry-After", strconv.FormatInt(seconds, 10))
+		}
+		return resp, nil
+
+	case FaultServerError:
+		closeBody()
+		statusCode := fault.StatusCode
+		if statusCode == 0 {
+			statusCode = http.StatusServiceUnavailable
+		}
+		return faultResponse(req, statusCode), nil
+	}
+
+	resp, err := transport.transport.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		return resp, err
+	}
+	switch fault.Kind {
+	case FaultTruncatedBody:
+		resp.Body = &truncatedBody{ReadCloser: resp.Body, remaining: fault.BodyBytes}
+	case FaultSlowBody:
+		resp.Body = &slowBody{ReadCloser: resp.Body, ctx: req.Context(), chunkSize: max(fault.BodyBytes, 1), delay: fault.Delay}
+	}
+	return resp, nil
+}
+
+// faultResponse returns a new error response to "req" with the specified status code.
+func faultResponse(req *http.Request, statusCode int) *http.Response {
+	body := fmt.Sprintf(`{"errors":[{"code":"injected_fault","message":"%s"}],"status_code":%d}`,
+		http.StatusText(statusCode), statusCode)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{CONTENT_TYPE: []string{APPLICATION_JSON}},
+		Body:          io.NopCloser(bytes.NewReader([]byte(body))),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// sleepWithContext waits for "delay", unless "ctx" is done first.
+func sleepWithContext(ctx context.Context, delay time.Duration) error {
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// truncatedBody is a response body that fails after "remaining" bytes.
+type truncatedBody struct {
+	io.ReadCloser
+	remaining int
+}
+
+func (body *truncatedBody) Read(p []byte) (int, error) {
+	if body.remaining <= 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	if len(p) > body.remaining {
+		p = p[:body.remaining]
+	}
+	n, err := body.ReadCloser.Read(p)
+	body.remaining -= n
+	return n, err
+}
+
+// slowBody is a response body that is delivered in chunks, after a delay before each chunk.
+type slowBody struct {
+	io.ReadCloser
+	ctx       context.Context
+	chunkSize int
+	delay     time.Duration
+}
+
+func (body *slowBody) Read(p []byte) (int, error) {
+	if err := sleepWithContext(body.ctx, body.delay); err != nil {
+		return 0, err
+	}
+	if len(p) > body.chunkSize {
+		p = p[:body.chunkSize]
+	}
+	return body.ReadCloser.Read(p)
+}
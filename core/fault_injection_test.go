@@ -0,0 +1,205 @@
+//go:build all || fast || basesvc || retries
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaultInjectorRetries(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusOK, APPLICATION_JSON, `{"name": "0123456789"}`)
+	defer server.Close()
+
+	injector := NewFaultInjector()
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	service.EnableRetries(3, 10*time.Millisecond)
+	service.SetHTTPClient(injector.Client(service.GetHTTPClient()))
+
+	// The faulted attempts are retried.
+	injector.Script(
+		&Fault{Kind: FaultTooManyRequests},
+		&Fault{Kind: FaultServerError, StatusCode: http.StatusBadGateway, Burst: 2},
+	)
+	resp, err := invokeTestRequest(t, service, NewRequestBuilder(GET), "/things", new(map[string]interface{}))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(1), server.requests.Load())
+	assert.Equal(t, 1, injector.Injected(FaultTooManyRequests))
+	assert.Equal(t, 2, injector.Injected(FaultServerError))
+
+	injector.Script(&Fault{Kind: FaultConnectionReset}, &Fault{Kind: FaultTLSHandshakeError}, nil)
+	_, err = invokeTestRequest(t, service, NewRequestBuilder(GET), "/things", new(map[string]interface{}))
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), server.requests.Load())
+
+	// Too many faults exhaust the retries.
+	injector.Script(&Fault{Kind: FaultServerError, Burst: 4})
+	resp, err = invokeTestRequest(t, service, NewRequestBuilder(GET), "/things", new(map[string]interface{}))
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(2), server.requests.Load())
+
+	// An untrusted certificate isn't retried.
+	injector.Script(&Fault{Kind: FaultTLSCertificateError})
+	_, err = invokeTestRequest(t, service, NewRequestBuilder(GET), "/things", new(map[string]interface{}))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "certificate signed by unknown authority")
+	_, err = invokeTestRequest(t, service, NewRequestBuilder(GET), "/things", new(map[string]interface{}))
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), server.requests.Load())
+}
+
+func TestFaultInjectorErrors(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusOK, APPLICATION_JSON, `{"name": "0123456789"}`)
+	defer server.Close()
+
+	injector := NewFaultInjector()
+	client := injector.Client(nil)
+
+	injector.Script(&Fault{Kind: FaultConnectionReset})
+	_, err := client.Get(server.URL)
+	assert.Contains(t, err.Error(), "read tcp: read: connection reset by peer")
+
+	injector.Script(&Fault{Kind: FaultTLSHandshakeError})
+	_, err = client.Get(server.URL)
+	assert.Contains(t, err.Error(), "remote error: tls: handshake failure")
+
+	// The "Retry-After" header is honored by the backoff policy.
+	injector.Script(&Fault{Kind: FaultTooManyRequests, RetryAfter: 1500 * time.Millisecond})
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+	assert.Equal(t, 2*time.Second, IBMCloudSDKBackoffPolicy(time.Second, 30*time.Second, 1, resp))
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "Too Many Requests")
+
+	assert.Zero(t, server.requests.Load())
+}
+
+func TestFaultInjectorLatency(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusOK, APPLICATION_JSON, `{"name": "0123456789"}`)
+	defer server.Close()
+
+	injector := NewFaultInjector()
+	client := injector.Client(nil)
+
+	injector.Script(&Fault{Kind: FaultLatency, Delay: 50 * time.Millisecond})
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	_ = resp.Body.Close()
+
+	// The latency is cut short by a timeout.
+	injector.Script(&Fault{Kind: FaultLatency, Delay: time.Minute})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, GET, server.URL, nil)
+	_, err = client.Do(req)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, int32(1), server.requests.Load())
+}
+
+func TestFaultInjectorBodies(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusOK, APPLICATION_JSON, `{"name": "0123456789"}`)
+	defer server.Close()
+
+	injector := NewFaultInjector()
+	client := injector.Client(nil)
+
+	injector.Script(&Fault{Kind: FaultTruncatedBody, BodyBytes: 10})
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	body, err := io.ReadAll(resp.Body)
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+	assert.Equal(t, `{"name": "`, string(body))
+	_ = resp.Body.Close()
+
+	injector.Script(&Fault{Kind: FaultSlowBody, BodyBytes: 5, Delay: 5 * time.Millisecond})
+	start := time.Now()
+	resp, err = client.Get(server.URL)
+	assert.Nil(t, err)
+	body, err = io.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, `{"name": "0123456789"}`, string(body))
+	assert.GreaterOrEqual(t, time.Since(start), 25*time.Millisecond)
+	_ = resp.Body.Close()
+
+	// A truncated body fails the service's request.
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	service.EnableRetries(3, 10*time.Millisecond)
+	service.SetHTTPClient(injector.Client(service.GetHTTPClient()))
+	injector.Script(&Fault{Kind: FaultTruncatedBody, BodyBytes: 10})
+	_, err = invokeTestRequest(t, service, NewRequestBuilder(GET), "/things", new(map[string]interface{}))
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(3), server.requests.Load())
+}
+
+func TestFaultInjectorRandom(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusOK, APPLICATION_JSON, `{"name": "0123456789"}`)
+	defer server.Close()
+
+	outcomes := func(seed uint64) (statusCodes []int) {
+		injector := NewFaultInjector()
+		injector.SetSeed(seed)
+		injector.AddFault(0.25, &Fault{Kind: FaultServerError})
+		injector.AddFault(0.5, &Fault{Kind: FaultTooManyRequests})
+		client := injector.Client(nil)
+		for i := 0; i < 100; i++ {
+			resp, err := client.Get(server.URL)
+			assert.Nil(t, err)
+			_ = resp.Body.Close()
+			statusCodes = append(statusCodes, resp.StatusCode)
+		}
+		assert.Greater(t, injector.Injected(FaultServerError), 10)
+		assert.Less(t, injector.Injected(FaultServerError), 40)
+		assert.Greater(t, injector.Injected(FaultTooManyRequests), 25)
+		assert.Less(t, injector.Injected(FaultTooManyRequests), 50)
+		return
+	}
+
+	// The faults are reproducible.
+	assert.Equal(t, outcomes(42), outcomes(42))
+	assert.NotEqual(t, outcomes(42), outcomes(43))
+
+	injector := NewFaultInjector()
+	injector.AddFault(1, &Fault{Kind: FaultServerError})
+	resp, err := injector.Client(nil).Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestFaultKindString(t *testing.T) {
+	assert.Equal(t, "slow-body", FaultSlowBody.String())
+	assert.Equal(t, "fault-99", FaultKind(99).String())
+	assert.True(t, strings.HasPrefix(FaultConnectionReset.String(), "connection"))
+}
@@ -0,0 +1,571 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	harVersion = "1.2"
+
+	// harTokenRequestComment is the comment of the entries that describe
+	// the token requests made by an authenticator.
+	harTokenRequestComment = "Token request made by the '%s' authenticator"
+
+	// harBodyOmittedComment is the comment of a body whose content isn't recorded,
+	// and harBodyNotReadComment the comment of a response body that hasn't been read yet.
+	harBodyOmittedComment = "The body was omitted: binary/compressed."
+	harBodyNotReadComment = "The body has not been read."
+)
+
+// HARRecorder records HTTP traffic in the HAR (HTTP Archive) 1.2 format, which can be
+// loaded by browser developer tools and other HTTP tooling. Once a HARRecorder is set as
+// the Go core's recorder (see SetHARRecorder), it records each request sent by a service
+// (including each retry attempt) and each token request made by an authenticator,
+// along with the response and the timing of the exchange.
+//
+// An exchange is recorded as soon as its response headers are received, and its bodies are
+// recorded as they are read (the record is complete once the response body has been read or
+// closed). Compressed bodies are decompressed and secrets are redacted with RedactSecrets;
+// the content of a body that isn't text (or can't be decompressed) is omitted.
+type HARRecorder struct {
+	maxBodySize int64
+
+	mutex   sync.Mutex
+	entries []*harEntry
+}
+
+// NewHARRecorder returns a new HARRecorder that records at most "maxBodySize" bytes
+// of each request and response body (0 for no limit).
+func NewHARRecorder(maxBodySize int64) *HARRecorder {
+	return &HARRecorder{maxBodySize: maxBodySize}
+}
+
+// Len returns the number of exchanges recorded so far.
+func (recorder *HARRecorder) Len() int {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return len(recorder.entries)
+}
+
+// Reset discards the exchanges recorded so far.
+func (recorder *HARRecorder) Reset() {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.entries = nil
+}
+
+// WriteTo writes the exchanges recorded so far to "w" as a HAR file.
+func (recorder *HARRecorder) WriteTo(w io.Writer) (int64, error) {
+	// The entries are copied, as they may be updated while they are written.
+	recorder.mutex.Lock()
+	entries := make([]*harEntry, len(recorder.entries))
+	for i, entry := range recorder.entries {
+		entryCopy := *entry
+		entries[i] = &entryCopy
+	}
+	recorder.mutex.Unlock()
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].started.Before(entries[j].started)
+	})
+
+	har := harFile{Log: harLog{
+		Version: harVersion,
+		Creator: harCreator{Name: sdkName, Version: __VERSION__},
+		Entries: entries,
+	}}
+	buf, err := json.MarshalIndent(har, "", "  ")
+	if err != nil {
+		return 0, SDKErrorf(err, "", "har-marshal-error", getComponentInfo())
+	}
+	n, err := w.Write(append(buf, '\n'))
+	if err != nil {
+		err = SDKErrorf(err, "", "har-write-error", getComponentInfo())
+	}
+	return int64(n), err
+}
+
+// Save writes the exchanges recorded so far to the file named "filename" as a HAR file.
+func (recorder *HARRecorder) Save(filename string) error {
+	var buf bytes.Buffer
+	_, err := recorder.WriteTo(&buf)
+	if err != nil {
+		return RepurposeSDKProblem(err, "har-save-error")
+	}
+	err = os.WriteFile(filename, buf.Bytes(), 0600)
+	if err != nil {
+		return SDKErrorf(err, "", "har-save-error", getComponentInfo())
+	}
+	return nil
+}
+
+func (recorder *HARRecorder) add(entry *harEntry) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.entries = append(recorder.entries, entry)
+}
+
+// update replaces the recorded "entry" with "updated".
+func (recorder *HARRecorder) update(entry *harEntry, updated *harEntry) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	*entry = *updated
+}
+
+// sdkHARRecorder holds the HARRecorder used by the Go core library (nil if traffic isn't recorded).
+var sdkHARRecorder *HARRecorder
+
+// SetHARRecorder sets the specified HARRecorder instance as the recorder to be used by the Go core library
+// (nil to stop recording).
+func SetHARRecorder(recorder *HARRecorder) {
+	sdkHARRecorder = recorder
+}
+
+// GetHARRecorder returns the HARRecorder instance currently used by the Go core (nil if none).
+func GetHARRecorder() *HARRecorder {
+	return sdkHARRecorder
+}
+
+// recordingClient returns "client", or a copy of "client" whose traffic is recorded by the Go core's
+// HARRecorder (if any). "comment" is an optional comment added to each recorded exchange.
+func recordingClient(client *http.Client, comment string) *http.Client {
+	recorder := GetHARRecorder()
+	if recorder == nil {
+		return client
+	}
+	return clientWithTransport(client, func(transport http.RoundTripper) http.RoundTripper {
+		return &harTransport{base: transport, recorder: recorder, comment: comment}
+	})
+}
+
+// The HAR 1.2 format (see http://www.softwareishard.com/blog/har-12-spec/).
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string      `json:"version"`
+	Creator harCreator  `json:"creator"`
+	Entries []*harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	ServerIPAddress string      `json:"serverIPAddress,omitempty"`
+	Comment         string      `json:"comment,omitempty"`
+
+	started time.Time
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int64          `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int64          `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+	Comment     string         `json:"comment,omitempty"`
+}
+
+type harContent struct {
+	Size        int64  `json:"size"`
+	Compression int64  `json:"compression,omitempty"`
+	MimeType    string `json:"mimeType"`
+	Text        string `json:"text,omitempty"`
+	Comment     string `json:"comment,omitempty"`
+}
+
+type harTimings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+	SSL     float64 `json:"ssl"`
+}
+
+// harTransport is an http.RoundTripper that records each exchange with a HARRecorder.
+type harTransport struct {
+	base     http.RoundTripper
+	recorder *HARRecorder
+	comment  string
+}
+
+func (t *harTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	exchange := &harExchange{recorder: t.recorder, comment: t.comment, req: req, start: time.Now()}
+	tracedReq := req.WithContext(httptrace.WithClientTrace(req.Context(), exchange.clientTrace()))
+	if !IsNil(req.Body) && req.Body != http.NoBody {
+		exchange.requestBody = &harBody{ReadCloser: req.Body, maxSize: t.recorder.maxBodySize}
+		tracedReq.Body = exchange.requestBody
+	}
+
+	resp, err := t.base.RoundTrip(tracedReq)
+	if err != nil {
+		exchange.finish(nil, err)
+		return nil, err
+	}
+
+	// The exchange is recorded now, so that it is recorded even if the response body is never read,
+	// and its record is completed once the response body has been read (or closed).
+	exchange.responseBody = &harBody{ReadCloser: resp.Body, maxSize: t.recorder.maxBodySize,
+		done: func() { exchange.finish(resp, nil) }}
+	exchange.recordResponseHeaders(resp)
+	resp.Body = exchange.responseBody
+	return resp, nil
+}
+
+// harExchange keeps track of a request/response exchange until it can be recorded.
+type harExchange struct {
+	recorder     *HARRecorder
+	comment      string
+	req          *http.Request
+	requestBody  *harBody
+	responseBody *harBody
+	once         sync.Once
+	entry        *harEntry
+
+	mutex                                        sync.Mutex
+	start, dnsStart, dnsDone                     time.Time
+	connectStart, connectDone, tlsStart, tlsDone time.Time
+	gotConn, wroteRequest, firstByte             time.Time
+	serverIPAddress                              string
+}
+
+func (exchange *harExchange) clientTrace() *httptrace.ClientTrace {
+	record := func(f func()) {
+		exchange.mutex.Lock()
+		defer exchange.mutex.Unlock()
+		f()
+	}
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { record(func() { exchange.dnsStart = time.Now() }) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { record(func() { exchange.dnsDone = time.Now() }) },
+		ConnectStart: func(string, string) {
+			record(func() {
+				if exchange.connectStart.IsZero() {
+					exchange.connectStart = time.Now()
+				}
+			})
+		},
+		ConnectDone:       func(string, string, error) { record(func() { exchange.connectDone = time.Now() }) },
+		TLSHandshakeStart: func() { record(func() { exchange.tlsStart = time.Now() }) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { record(func() { exchange.tlsDone = time.Now() }) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			record(func() {
+				exchange.gotConn = time.Now()
+				if host, _, err := net.SplitHostPort(info.Conn.RemoteAddr().String()); err == nil {
+					exchange.serverIPAddress = host
+				}
+			})
+		},
+		WroteRequest:         func(httptrace.WroteRequestInfo) { record(func() { exchange.wroteRequest = time.Now() }) },
+		GotFirstResponseByte: func() { record(func() { exchange.firstByte = time.Now() }) },
+	}
+}
+
+// recordResponseHeaders records the exchange once the headers of its response "resp" have been received.
+func (exchange *harExchange) recordResponseHeaders(resp *http.Response) {
+	exchange.mutex.Lock()
+	entry := exchange.newEntry(resp, nil, time.Now())
+	entry.Response.Content.Comment = harBodyNotReadComment
+	exchange.entry = entry
+	exchange.mutex.Unlock()
+	exchange.recorder.add(entry)
+}
+
+// finish records the exchange (or completes its record), once its outcome ("resp" or "err") is known.
+func (exchange *harExchange) finish(resp *http.Response, err error) {
+	exchange.once.Do(func() {
+		end := time.Now()
+		exchange.mutex.Lock()
+		entry := exchange.newEntry(resp, err, end)
+		recorded := exchange.entry
+		exchange.mutex.Unlock()
+		if recorded != nil {
+			exchange.recorder.update(recorded, entry)
+		} else {
+			exchange.recorder.add(entry)
+		}
+	})
+}
+
+// newEntry returns the entry that describes the exchange, whose outcome is "resp" or "err", at "end".
+func (exchange *harExchange) newEntry(resp *http.Response, err error, end time.Time) *harEntry {
+	req := exchange.req
+	redactedURL := RedactSecrets(req.URL.String())
+	entry := &harEntry{
+		StartedDateTime: exchange.start.Format(time.RFC3339Nano),
+		Time:            harDuration(exchange.start, end),
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         redactedURL,
+			HTTPVersion: req.Proto,
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(req.Header),
+			QueryString: []harNameValue{},
+			HeadersSize: -1,
+		},
+		Timings:         exchange.timings(end),
+		ServerIPAddress: exchange.serverIPAddress,
+		Comment:         exchange.comment,
+		started:         exchange.start,
+	}
+	if entry.Request.HTTPVersion == "" {
+		entry.Request.HTTPVersion = "HTTP/1.1"
+	}
+	if parsedURL, parseErr := url.Parse(redactedURL); parseErr == nil {
+		entry.Request.QueryString = harNameValues(parsedURL.Query())
+	}
+	if body := exchange.requestBody; body != nil {
+		entry.Request.BodySize = body.getSize()
+		text, _, comment := body.text(req.Header.Get(CONTENT_ENCODING))
+		entry.Request.PostData = &harPostData{
+			MimeType: req.Header.Get(CONTENT_TYPE),
+			Text:     text,
+			Comment:  comment,
+		}
+	}
+
+	entry.Response = harResponse{
+		Cookies:     []harNameValue{},
+		Headers:     []harNameValue{},
+		HeadersSize: -1,
+	}
+	if resp == nil {
+		// The "status" of a response that wasn't received is 0.
+		entry.Response.BodySize = -1
+		entry.Response.Comment = RedactSecrets(err.Error())
+		return entry
+	}
+	entry.Response.Status = resp.StatusCode
+	entry.Response.StatusText = http.StatusText(resp.StatusCode)
+	entry.Response.HTTPVersion = resp.Proto
+	entry.Response.Headers = harHeaders(resp.Header)
+	entry.Response.RedirectURL = RedactSecrets(resp.Header.Get("Location"))
+	entry.Response.BodySize = exchange.responseBody.getSize()
+	text, size, comment := exchange.responseBody.text(resp.Header.Get(CONTENT_ENCODING))
+	entry.Response.Content = harContent{
+		Size:        size,
+		Compression: size - entry.Response.BodySize,
+		MimeType:    resp.Header.Get(CONTENT_TYPE),
+		Text:        text,
+		Comment:     comment,
+	}
+	return entry
+}
+
+// timings returns the timings of the exchange, which ended at "end".
+func (exchange *harExchange) timings(end time.Time) harTimings {
+	timings := harTimings{
+		DNS:     harDuration(exchange.dnsStart, exchange.dnsDone),
+		Connect: harDuration(exchange.connectStart, exchange.connectDone),
+		SSL:     harDuration(exchange.tlsStart, exchange.tlsDone),
+		Send:    max(harDuration(exchange.gotConn, exchange.wroteRequest), 0),
+		Wait:    max(harDuration(exchange.wroteRequest, exchange.firstByte), 0),
+		Receive: max(harDuration(exchange.firstByte, end), 0),
+	}
+
+	// In HAR, the connect time includes the SSL time, and the time spent
+	// waiting for a connection excludes the DNS and connect times.
+	if timings.Connect >= 0 && timings.SSL >= 0 {
+		timings.Connect = harDuration(exchange.connectStart, exchange.tlsDone)
+	}
+	timings.Blocked = harDuration(exchange.start, exchange.gotConn)
+	if timings.Blocked >= 0 {
+		timings.Blocked = max(timings.Blocked-max(timings.DNS, 0)-max(timings.Connect, 0), 0)
+	}
+	return timings
+}
+
+// harDuration returns the number of milliseconds between "from" and "to", or -1 if either is unknown.
+func harDuration(from time.Time, to time.Time) float64 {
+	if from.IsZero() || to.IsZero() {
+		return -1
+	}
+	return float64(to.Sub(from)) / float64(time.Millisecond)
+}
+
+// harHeaders returns the HAR representation of "header", with secrets redacted.
+func harHeaders(header http.Header) []harNameValue {
+	headers := make([]harNameValue, 0, len(header))
+	for name, values := range header {
+		for _, value := range values {
+			headers = append(headers, harNameValue{Name: name, Value: redactHeader(name, value)})
+		}
+	}
+	sort.SliceStable(headers, func(i, j int) bool { return headers[i].Name < headers[j].Name })
+	return headers
+}
+
+// harNameValues returns the HAR representation of "values".
+func harNameValues(values map[string][]string) []harNameValue {
+	nameValues := []harNameValue{}
+	for name, list := range values {
+		for _, value := range list {
+			nameValues = append(nameValues, harNameValue{Name: name, Value: value})
+		}
+	}
+	sort.SliceStable(nameValues, func(i, j int) bool { return nameValues[i].Name < nameValues[j].Name })
+	return nameValues
+}
+
+// harBody is a request or response body whose content is captured (up to a maximum size) as it is read.
+type harBody struct {
+	io.ReadCloser
+	maxSize int64
+	done    func()
+
+	mutex   sync.Mutex
+	content []byte
+	size    int64
+}
+
+func (body *harBody) Read(p []byte) (n int, err error) {
+	n, err = body.ReadCloser.Read(p)
+	body.mutex.Lock()
+	body.size += int64(n)
+	if remaining := body.maxSize - int64(len(body.content)); body.maxSize <= 0 || remaining > 0 {
+		if body.maxSize > 0 && int64(n) > remaining {
+			body.content = append(body.content, p[:remaining]...)
+		} else {
+			body.content = append(body.content, p[:n]...)
+		}
+	}
+	body.mutex.Unlock()
+	if err == io.EOF && body.done != nil {
+		body.done()
+	}
+	return
+}
+
+func (body *harBody) Close() error {
+	err := body.ReadCloser.Close()
+	if body.done != nil {
+		body.done()
+	}
+	return err
+}
+
+func (body *harBody) getSize() int64 {
+	body.mutex.Lock()
+	defer body.mutex.Unlock()
+	return body.size
+}
+
+// text returns the captured content of the body, decompressed according to "contentEncoding",
+// as text with secrets redacted. The content of a body that isn't text (or that can't be decompressed)
+// is omitted, as its secrets can't be redacted. It also returns the size of the (decompressed) body
+// and a comment about the content, if needed.
+func (body *harBody) text(contentEncoding string) (text string, size int64, comment string) {
+	body.mutex.Lock()
+	content, size := body.content, body.size
+	body.mutex.Unlock()
+
+	truncated := int64(len(content)) < size
+	if truncated {
+		comment = fmt.Sprintf("The body was truncated to %d of %d bytes.", len(content), size)
+	}
+	contentEncoding = strings.ToLower(strings.TrimSpace(contentEncoding))
+	if contentEncoding != "" && contentEncoding != "identity" {
+		reader, err := newDecompressionReader(contentEncoding, bytes.NewReader(content))
+		if err != nil || reader == nil {
+			return "", size, harJoinComments(comment, harBodyOmittedComment)
+		}
+		// Whatever could be decompressed from a truncated body is kept.
+		decompressed, err := io.ReadAll(reader)
+		if err != nil && (!truncated || len(decompressed) == 0) {
+			return "", size, harJoinComments(comment, harBodyOmittedComment)
+		}
+		content = decompressed
+		if !truncated {
+			size = int64(len(decompressed))
+		}
+	}
+	if truncated {
+		// Don't let the truncation of a multi-byte character turn text into binary.
+		for i := 1; i < utf8.UTFMax && !utf8.Valid(content) && len(content) > i; i++ {
+			if utf8.Valid(content[:len(content)-i]) {
+				content = content[:len(content)-i]
+			}
+		}
+	}
+	if !utf8.Valid(content) {
+		return "", size, harJoinComments(comment, harBodyOmittedComment)
+	}
+	return RedactSecrets(string(content)), size, comment
+}
+
+// harJoinComments returns the non-empty comments in "comments", separated by spaces.
+func harJoinComments(comments ...string) string {
+	var nonEmpty []string
+	for _, comment := range comments {
+		if comment != "" {
+			nonEmpty = append(nonEmpty, comment)
+		}
+	}
+	return strings.Join(nonEmpty, " ")
+}
@@ -0,0 +1,339 @@
+//go:build all || fast || basesvc || retries
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// readHAR returns the HAR file written by "recorder".
+func readHAR(t *testing.T, recorder *HARRecorder) harFile {
+	var buf bytes.Buffer
+	_, err := recorder.WriteTo(&buf)
+	assert.Nil(t, err)
+	var har harFile
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &har))
+	return har
+}
+
+func TestHARRecorder(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/identity/token" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"access_token": "token", "token_type": "Bearer", "expires_in": 3600, "expiration": %d}`,
+				GetCurrentTime()+3600)
+			return
+		}
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set(CONTENT_TYPE, "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"name": "my-instance", "secret": "s3cr3t"}`)
+	}))
+	defer server.Close()
+
+	recorder := NewHARRecorder(0)
+	SetHARRecorder(recorder)
+	defer SetHARRecorder(nil)
+	assert.Equal(t, recorder, GetHARRecorder())
+
+	authenticator, err := NewIamAuthenticatorBuilder().SetApiKey("my-apikey").SetURL(server.URL).Build()
+	assert.Nil(t, err)
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: authenticator,
+	})
+	assert.Nil(t, err)
+	service.EnableRetries(1, 10*time.Millisecond)
+
+	builder := NewRequestBuilder(POST)
+	_, _ = builder.ResolveRequestURL(server.URL, "/instances", nil)
+	builder.AddQuery("apikey", "my-apikey")
+	builder.AddHeader(CONTENT_TYPE, "application/json")
+	_, _ = builder.SetBodyContentJSON(map[string]interface{}{"name": "my-instance", "password": "my-password"})
+	req, _ := builder.Build()
+	var result map[string]interface{}
+	_, err = service.Request(req, &result)
+	assert.Nil(t, err)
+
+	// The token request and each attempt to send the request are recorded.
+	assert.Equal(t, 3, recorder.Len())
+	har := readHAR(t, recorder)
+	assert.Equal(t, "1.2", har.Log.Version)
+	assert.Equal(t, harCreator{Name: sdkName, Version: __VERSION__}, har.Log.Creator)
+	entries := har.Log.Entries
+	assert.Len(t, entries, 3)
+
+	token := entries[0]
+	assert.Equal(t, "Token request made by the 'iam' authenticator", token.Comment)
+	assert.Equal(t, POST, token.Request.Method)
+	assert.Equal(t, server.URL+"/identity/token", token.Request.URL)
+	assert.Contains(t, token.Request.PostData.Text, "apikey=[redacted]")
+	assert.NotContains(t, token.Request.PostData.Text, "my-apikey")
+	assert.Equal(t, http.StatusOK, token.Response.Status)
+	assert.Contains(t, token.Response.Content.Text, `"access_token":"[redacted]"`)
+
+	for i, entry := range entries[1:] {
+		assert.Empty(t, entry.Comment)
+		assert.Equal(t, POST, entry.Request.Method)
+		assert.Equal(t, server.URL+"/instances?apikey=[redacted]", entry.Request.URL)
+		assert.Equal(t, "HTTP/1.1", entry.Request.HTTPVersion)
+		assert.Equal(t, []harNameValue{{Name: "apikey", Value: "[redacted]"}}, entry.Request.QueryString)
+		assert.Contains(t, entry.Request.Headers, harNameValue{Name: "Authorization", Value: "[redacted]"})
+		assert.Equal(t, "application/json", entry.Request.PostData.MimeType)
+		assert.Equal(t, `{"name":"my-instance","password":"[redacted]"}`+"\n", entry.Request.PostData.Text)
+		assert.Equal(t, int64(len(`{"name":"my-instance","password":"my-password"}`)+1), entry.Request.BodySize)
+		assert.Equal(t, "127.0.0.1", entry.ServerIPAddress)
+		assert.Greater(t, entry.Time, 0.0)
+		assert.GreaterOrEqual(t, entry.Timings.Wait, 0.0)
+		_, err = time.Parse(time.RFC3339Nano, entry.StartedDateTime)
+		assert.Nil(t, err)
+		if i == 0 {
+			assert.Equal(t, http.StatusServiceUnavailable, entry.Response.Status)
+			assert.Equal(t, "Service Unavailable", entry.Response.StatusText)
+		}
+	}
+	response := entries[2].Response
+	assert.Equal(t, http.StatusOK, response.Status)
+	assert.Equal(t, "HTTP/1.1", response.HTTPVersion)
+	assert.Contains(t, response.Headers, harNameValue{Name: CONTENT_TYPE, Value: "application/json"})
+	assert.Equal(t, "application/json", response.Content.MimeType)
+	assert.Equal(t, `{"name": "my-instance", "secret":"[redacted]"}`, response.Content.Text)
+	assert.Equal(t, int64(43), response.BodySize)
+	assert.Equal(t, int64(43), response.Content.Size)
+
+	// The HAR file may be saved.
+	filename := filepath.Join(t.TempDir(), "traffic.har")
+	assert.Nil(t, recorder.Save(filename))
+	saved, err := os.ReadFile(filename)
+	assert.Nil(t, err)
+	var buf bytes.Buffer
+	_, _ = recorder.WriteTo(&buf)
+	assert.Equal(t, buf.String(), string(saved))
+
+	// Once the recorder is removed, nothing else is recorded.
+	recorder.Reset()
+	assert.Zero(t, recorder.Len())
+	SetHARRecorder(nil)
+	req, _ = builder.Build()
+	_, err = service.Request(req, nil)
+	assert.Nil(t, err)
+	assert.Zero(t, recorder.Len())
+}
+
+func TestHARRecorderBodies(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	body := strings.Repeat("0123456789", 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(headerNameAcceptEncoding) == "" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprint(w, body)
+			return
+		}
+		w.Header().Set(CONTENT_ENCODING, "gzip")
+		w.WriteHeader(http.StatusOK)
+		writer := gzip.NewWriter(w)
+		fmt.Fprint(writer, body)
+		_ = writer.Close()
+	}))
+	defer server.Close()
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+
+	recorder := NewHARRecorder(25)
+	SetHARRecorder(recorder)
+	defer SetHARRecorder(nil)
+
+	// The response body is recorded as it is read.
+	send := func(req *http.Request) {
+		var result io.ReadCloser
+		_, err := service.Request(req, &result)
+		assert.Nil(t, err)
+		_, err = io.ReadAll(result)
+		assert.Nil(t, err)
+		assert.Nil(t, result.Close())
+	}
+
+	// Bodies are truncated to the maximum size.
+	builder := NewRequestBuilder(PUT)
+	_, _ = builder.ResolveRequestURL(server.URL, "/data", nil)
+	_, _ = builder.SetBodyContentString(body)
+	req, _ := builder.Build()
+	send(req)
+
+	entry := readHAR(t, recorder).Log.Entries[0]
+	assert.Equal(t, body[:25], entry.Request.PostData.Text)
+	assert.Equal(t, "The body was truncated to 25 of 100 bytes.", entry.Request.PostData.Comment)
+	assert.Equal(t, int64(100), entry.Request.BodySize)
+	assert.Equal(t, body[:25], entry.Response.Content.Text)
+	assert.Equal(t, "The body was truncated to 25 of 100 bytes.", entry.Response.Content.Comment)
+	assert.Equal(t, int64(100), entry.Response.Content.Size)
+
+	// Compressed bodies are recorded decompressed, unless too little of them was captured.
+	recorder = NewHARRecorder(0)
+	SetHARRecorder(recorder)
+	service.SetEnableResponseDecompression(true)
+	req, _ = builder.Build()
+	send(req)
+
+	response := readHAR(t, recorder).Log.Entries[0].Response
+	assert.Equal(t, body, response.Content.Text)
+	assert.Empty(t, response.Content.Comment)
+	assert.Equal(t, int64(100), response.Content.Size)
+	assert.Equal(t, 100-response.BodySize, response.Content.Compression)
+
+	recorder = NewHARRecorder(10)
+	SetHARRecorder(recorder)
+	req, _ = builder.Build()
+	send(req)
+
+	response = readHAR(t, recorder).Log.Entries[0].Response
+	assert.Empty(t, response.Content.Text)
+	assert.Equal(t, "The body was truncated to 10 of "+strconv.FormatInt(response.BodySize, 10)+" bytes. "+
+		harBodyOmittedComment, response.Content.Comment)
+	assert.Equal(t, response.BodySize, response.Content.Size)
+	assert.Zero(t, response.Content.Compression)
+}
+
+func TestHARRecorderError(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	recorder := NewHARRecorder(0)
+	SetHARRecorder(recorder)
+	defer SetHARRecorder(nil)
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+
+	builder := NewRequestBuilder(GET)
+	_, _ = builder.ResolveRequestURL(server.URL, "/data", nil)
+	req, _ := builder.Build()
+	_, err = service.Request(req, nil)
+	assert.NotNil(t, err)
+
+	entries := readHAR(t, recorder).Log.Entries
+	assert.Len(t, entries, 1)
+	assert.Zero(t, entries[0].Response.Status)
+	assert.Equal(t, int64(-1), entries[0].Response.BodySize)
+	assert.Contains(t, entries[0].Response.Comment, "connection refused")
+	assert.Nil(t, entries[0].Request.PostData)
+}
+
+func TestHARRecorderCompressedSecrets(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/binary" {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte{0xff, 0xfe, 0x00, 0x01})
+			return
+		}
+		w.Header().Set(CONTENT_TYPE, APPLICATION_JSON)
+		w.Header().Set(CONTENT_ENCODING, "gzip")
+		w.WriteHeader(http.StatusOK)
+		writer := gzip.NewWriter(w)
+		fmt.Fprint(writer, `{"access_token": "my-secret-token"}`)
+		_ = writer.Close()
+	}))
+	defer server.Close()
+
+	recorder := NewHARRecorder(0)
+	client := &http.Client{Transport: &harTransport{base: http.DefaultTransport, recorder: recorder}}
+	get := func(path string) {
+		req, _ := http.NewRequest(GET, server.URL+path, nil)
+		// The transport doesn't decompress the response if the request accepts compressed responses.
+		req.Header.Set(headerNameAcceptEncoding, "gzip")
+		resp, err := client.Do(req)
+		assert.Nil(t, err)
+		_, _ = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+	}
+
+	// The secrets of a compressed body are redacted.
+	get("/token")
+	content := readHAR(t, recorder).Log.Entries[0].Response.Content
+	assert.Contains(t, content.Text, "[redacted]")
+	assert.NotContains(t, content.Text, "my-secret-token")
+
+	// The content of a binary body is omitted.
+	recorder.Reset()
+	get("/binary")
+	content = readHAR(t, recorder).Log.Entries[0].Response.Content
+	assert.Empty(t, content.Text)
+	assert.Equal(t, harBodyOmittedComment, content.Comment)
+	assert.Equal(t, int64(4), content.Size)
+}
+
+func TestHARRecorderUnreadResponse(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, "not found")
+	}))
+	defer server.Close()
+
+	recorder := NewHARRecorder(0)
+	client := &http.Client{Transport: &harTransport{base: http.DefaultTransport, recorder: recorder}}
+
+	// The exchange is recorded as soon as the response headers are received.
+	head, err := client.Head(server.URL)
+	assert.Nil(t, err)
+	defer head.Body.Close()
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	entries := readHAR(t, recorder).Log.Entries
+	assert.Len(t, entries, 2)
+	assert.Equal(t, HEAD, entries[0].Request.Method)
+	assert.Equal(t, http.StatusNotFound, entries[1].Response.Status)
+	assert.Equal(t, harBodyNotReadComment, entries[1].Response.Content.Comment)
+
+	// Its record is completed once the response body is read.
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, "not found", string(body))
+	entries = readHAR(t, recorder).Log.Entries
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "not found", entries[1].Response.Content.Text)
+	assert.Empty(t, entries[1].Response.Content.Comment)
+}
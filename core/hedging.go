@@ -0,0 +1,247 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// HedgingPolicy describes how hedged requests are sent for a service (see ServiceOptions.Hedging)
+// or for a single request (see RequestBuilder.Hedging and WithHedgingPolicy).
+//
+// When a request is hedged, a duplicate request is sent if no response has been received
+// after the hedging delay, and so on up to the maximum number of hedges. The first response
+// received is used and the other requests are cancelled. The number of duplicate requests
+// sent is reported by the DetailedResponse.Hedges field.
+//
+// Since a hedged request may be processed more than once by the server, only requests
+// with an idempotent method should be hedged. By default, only GET and HEAD requests are hedged.
+// A request with a body is only hedged if its body can be re-read (http.Request.GetBody).
+type HedgingPolicy struct {
+	delay         time.Duration
+	maxHedges     int
+	maxConcurrent int32
+	methods       []string
+
+	// The number of hedged requests currently in progress.
+	inFlight atomic.Int32
+}
+
+// NewHedgingPolicy returns a new HedgingPolicy that sends up to "maxHedges" duplicate requests
+// (1 if "maxHedges" is not positive), "delay" apart. At most "maxConcurrent" duplicate requests
+// may be in progress at any time among all the requests that use the policy; if "maxConcurrent"
+// is not positive, there is no limit.
+func NewHedgingPolicy(delay time.Duration, maxHedges int, maxConcurrent int) *HedgingPolicy {
+	if maxHedges <= 0 {
+		maxHedges = 1
+	}
+	if maxConcurrent < 0 {
+		maxConcurrent = 0
+	}
+	return &HedgingPolicy{
+		delay:         delay,
+		maxHedges:     maxHedges,
+		maxConcurrent: int32(maxConcurrent), // #nosec G115
+		methods:       []string{http.MethodGet, http.MethodHead},
+	}
+}
+
+// WithMethods sets the HTTP methods of the requests that may be hedged (GET and HEAD by default).
+func (policy *HedgingPolicy) WithMethods(methods ...string) *HedgingPolicy {
+	policy.methods = methods
+	return policy
+}
+
+// GetDelay returns the time waited for a response before sending each duplicate request.
+func (policy *HedgingPolicy) GetDelay() time.Duration {
+	return policy.delay
+}
+
+// GetMaxHedges returns the maximum number of duplicate requests sent for a request.
+func (policy *HedgingPolicy) GetMaxHedges() int {
+	return policy.maxHedges
+}
+
+// GetMaxConcurrent returns the maximum number of duplicate requests in progress at any time (0 if unlimited).
+func (policy *HedgingPolicy) GetMaxConcurrent() int {
+	return int(policy.maxConcurrent)
+}
+
+// appliesTo returns true if "req" may be hedged.
+func (policy *HedgingPolicy) appliesTo(req *http.Request) bool {
+	if !slices.ContainsFunc(policy.methods, func(m string) bool { return strings.EqualFold(m, req.Method) }) {
+		return false
+	}
+	return IsNil(req.Body) || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// acquire reserves a slot for a duplicate request, and returns false if none is available.
+func (policy *HedgingPolicy) acquire() bool {
+	for {
+		n := policy.inFlight.Load()
+		if policy.maxConcurrent > 0 && n >= policy.maxConcurrent {
+			return false
+		}
+		if policy.inFlight.CompareAndSwap(n, n+1) {
+			return true
+		}
+	}
+}
+
+// release frees the slot of a duplicate request.
+func (policy *HedgingPolicy) release() {
+	policy.inFlight.Add(-1)
+}
+
+type hedgingPolicyKey struct{}
+
+// WithHedgingPolicy returns a copy of "ctx" that carries "policy", so that the requests
+// associated with the returned Context are hedged according to "policy" rather than the
+// service's HedgingPolicy. A nil policy disables hedging for those requests.
+func WithHedgingPolicy(ctx context.Context, policy *HedgingPolicy) context.Context {
+	return context.WithValue(ctx, hedgingPolicyKey{}, policy)
+}
+
+// hedgingPolicy returns the HedgingPolicy to be used to send "req", or nil if it must not be hedged.
+func (service *BaseService) hedgingPolicy(req *http.Request) *HedgingPolicy {
+	policy := service.Options.Hedging
+	if p, ok := req.Context().Value(hedgingPolicyKey{}).(*HedgingPolicy); ok {
+		policy = p
+	}
+	if policy == nil || !policy.appliesTo(req) {
+		return nil
+	}
+	return policy
+}
+
+// hedgeResult is the outcome of one of the requests sent by sendHedgedRequest.
+type hedgeResult struct {
+	index int
+	resp  *http.Response
+	err   error
+	state *requestState
+}
+
+// sendHedgedRequest sends "req" with "client", along with duplicate requests
+// as dictated by "policy", and returns the first response received. The other requests are
+// cancelled. "state" is updated with the number of duplicate requests that were sent and
+// with the attempts made by the request that produced the response.
+func sendHedgedRequest(client *http.Client, req *http.Request, state *requestState,
+	policy *HedgingPolicy) (*http.Response, error) {
+	results := make(chan hedgeResult, policy.maxHedges+1)
+	var cancels []context.CancelFunc
+
+	send := func(hedge bool) error {
+		ctx, cancel := context.WithCancel(req.Context())
+		hedgeState := state.clone()
+		r := req.Clone(withRequestState(ctx, hedgeState))
+		if hedge && !IsNil(req.Body) && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				cancel()
+				return err
+			}
+			r.Body = body
+		}
+
+		index := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			resp, err := hedgeState.retryClient(client).Do(r)
+			if hedge {
+				policy.release()
+			}
+			results <- hedgeResult{index: index, resp: resp, err: err, state: hedgeState}
+		}()
+		return nil
+	}
+
+	_ = send(false)
+	inFlight := 1
+	timer := time.NewTimer(policy.delay)
+	defer timer.Stop()
+
+	var winner hedgeResult
+	for {
+		select {
+		case result := <-results:
+			inFlight--
+			winner = result
+			if result.err != nil && inFlight > 0 {
+				// Wait for one of the other requests.
+				cancels[result.index]()
+				continue
+			}
+		case <-timer.C:
+			if len(cancels) <= policy.maxHedges && policy.acquire() {
+				GetLogger().Debug("No response after %s, sending hedged request #%d\n", policy.delay.String(), len(cancels))
+				if err := send(true); err != nil {
+					policy.release()
+				} else {
+					inFlight++
+				}
+			}
+			if len(cancels) <= policy.maxHedges {
+				timer.Reset(policy.delay)
+			}
+			continue
+		}
+		break
+	}
+
+	// Cancel the other requests, then wait for them to finish (which they do promptly
+	// once cancelled) and discard their responses, so that nothing outlives this call.
+	for i, cancel := range cancels {
+		if i != winner.index {
+			cancel()
+		}
+	}
+	for ; inFlight > 0; inFlight-- {
+		if result := <-results; result.resp != nil {
+			_ = result.resp.Body.Close()
+		}
+	}
+
+	state.hedges = len(cancels) - 1
+	state.attempts = winner.state.attempts
+	state.history = winner.state.history
+
+	if winner.err != nil {
+		cancels[winner.index]()
+		return nil, winner.err
+	}
+
+	// The winning request's Context must remain valid until its response body has been read.
+	winner.resp.Body = &cancelOnCloseBody{ReadCloser: winner.resp.Body, cancel: cancels[winner.index]}
+	return winner.resp, nil
+}
+
+// cancelOnCloseBody is a response body that cancels the request's Context when closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (body *cancelOnCloseBody) Close() error {
+	err := body.ReadCloser.Close()
+	body.cancel()
+	return err
+}
@@ -0,0 +1,238 @@
+//go:build all || fast || basesvc || retries
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newHedgingTestServer returns a server that doesn't respond to the first request
+// until it is cancelled, and responds immediately to the other requests with their
+// number and body. "cancelled" receives a value when the first request is cancelled.
+func newHedgingTestServer(requests *atomic.Int32, cancelled chan struct{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		if n == 1 {
+			select {
+			case <-r.Context().Done():
+				cancelled <- struct{}{}
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"request": %d, "body": "%s"}`, n, body)))
+	}))
+}
+
+func TestHedgingPolicy(t *testing.T) {
+	policy := NewHedgingPolicy(time.Second, 0, -1)
+	assert.Equal(t, time.Second, policy.GetDelay())
+	assert.Equal(t, 1, policy.GetMaxHedges())
+	assert.Equal(t, 0, policy.GetMaxConcurrent())
+
+	get, _ := http.NewRequest(GET, "https://myservice/api", nil)
+	post, _ := http.NewRequest(POST, "https://myservice/api", strings.NewReader("body"))
+	head, _ := http.NewRequest(HEAD, "https://myservice/api", nil)
+	assert.True(t, policy.appliesTo(get))
+	assert.True(t, policy.appliesTo(head))
+	assert.False(t, policy.appliesTo(post))
+
+	policy.WithMethods("post")
+	assert.False(t, policy.appliesTo(get))
+	assert.True(t, policy.appliesTo(post))
+	post.GetBody = nil
+	assert.False(t, policy.appliesTo(post))
+
+	policy = NewHedgingPolicy(time.Second, 3, 2)
+	assert.True(t, policy.acquire())
+	assert.True(t, policy.acquire())
+	assert.False(t, policy.acquire())
+	policy.release()
+	assert.True(t, policy.acquire())
+}
+
+func TestHedgingService(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	var requests atomic.Int32
+	cancelled := make(chan struct{}, 1)
+	server := newHedgingTestServer(&requests, cancelled)
+	defer server.Close()
+
+	policy := NewHedgingPolicy(20*time.Millisecond, 1, 0)
+	service := newTestService(t, &ServiceOptions{
+		URL:     server.URL,
+		Hedging: policy,
+	})
+	assert.Equal(t, policy, service.GetHedging())
+
+	start := time.Now()
+	detailedResponse, err := invokeTestRequest(t, service, NewRequestBuilder(GET), "/instances", new(map[string]interface{}))
+	assert.Nil(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, http.StatusOK, detailedResponse.StatusCode)
+	assert.Equal(t, 1, detailedResponse.Hedges)
+	assert.Equal(t, float64(2), detailedResponse.Result.(map[string]interface{})["request"])
+	assert.Equal(t, int32(2), requests.Load())
+
+	// The slow request is cancelled.
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		assert.Fail(t, "the slow request wasn't cancelled")
+	}
+
+	// A fast response doesn't need to be hedged.
+	detailedResponse, err = invokeTestRequest(t, service, NewRequestBuilder(GET), "/instances", new(map[string]interface{}))
+	assert.Nil(t, err)
+	assert.Zero(t, detailedResponse.Hedges)
+	assert.Equal(t, int32(3), requests.Load())
+
+	// The hedging slot is released once the duplicate request completes.
+	assert.Zero(t, policy.inFlight.Load())
+}
+
+func TestHedgingWaitsForLosers(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			time.Sleep(50 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	policy := NewHedgingPolicy(10*time.Millisecond, 2, 0)
+	service := newTestService(t, &ServiceOptions{
+		URL:     server.URL,
+		Hedging: policy,
+	})
+
+	// The original request wins, and the duplicate requests have finished by the time it returns.
+	detailedResponse, err := invokeTestRequest(t, service, NewRequestBuilder(GET), "/instances", new(map[string]interface{}))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, detailedResponse.StatusCode)
+	assert.Equal(t, 2, detailedResponse.Hedges)
+	assert.Zero(t, policy.inFlight.Load())
+}
+
+func TestHedgingMethods(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	var requests atomic.Int32
+	cancelled := make(chan struct{}, 1)
+	server := newHedgingTestServer(&requests, cancelled)
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	service.SetHedging(NewHedgingPolicy(10*time.Millisecond, 1, 0))
+
+	// POST requests aren't hedged by default, but a request may opt in.
+	builder := NewRequestBuilder(POST)
+	builder.Hedging = NewHedgingPolicy(10*time.Millisecond, 1, 0).WithMethods(POST)
+	_, _ = builder.SetBodyContent("text/plain", nil, nil, "hello")
+	detailedResponse, err := invokeTestRequest(t, service, builder, "/instances", new(map[string]interface{}))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, detailedResponse.Hedges)
+	assert.Equal(t, "hello", detailedResponse.Result.(map[string]interface{})["body"])
+	<-cancelled
+
+	// A request may also opt out through its Context.
+	requests.Store(0)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	builder = NewRequestBuilder(GET).WithContext(WithHedgingPolicy(ctx, nil))
+	_, err = invokeTestRequest(t, service, builder, "/instances", new(map[string]interface{}))
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+	<-cancelled
+}
+
+func TestHedgingMaxConcurrent(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	var requests atomic.Int32
+	cancelled := make(chan struct{}, 1)
+	server := newHedgingTestServer(&requests, cancelled)
+	defer server.Close()
+
+	policy := NewHedgingPolicy(10*time.Millisecond, 2, 1)
+	service := newTestService(t, &ServiceOptions{
+		URL:     server.URL,
+		Hedging: policy,
+	})
+
+	// No duplicate request is sent while the cap is reached.
+	assert.True(t, policy.acquire())
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := invokeTestRequest(t, service, NewRequestBuilder(GET).WithContext(ctx), "/instances",
+		new(map[string]interface{}))
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), requests.Load())
+	<-cancelled
+
+	policy.release()
+	requests.Store(0)
+	detailedResponse, err := invokeTestRequest(t, service, NewRequestBuilder(GET), "/instances", new(map[string]interface{}))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, detailedResponse.Hedges)
+	<-cancelled
+}
+
+func TestHedgingWithRetries(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch requests.Add(1) {
+		case 1:
+			<-r.Context().Done()
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{
+		URL:     server.URL,
+		Hedging: NewHedgingPolicy(20*time.Millisecond, 1, 0),
+	})
+	service.EnableRetries(2, 10*time.Millisecond)
+
+	// The attempts are those of the duplicate request, which was retried.
+	detailedResponse, err := invokeTestRequest(t, service, NewRequestBuilder(GET), "/instances", new(map[string]interface{}))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, detailedResponse.StatusCode)
+	assert.Equal(t, 1, detailedResponse.Hedges)
+	assert.Len(t, detailedResponse.Attempts, 2)
+	assert.Equal(t, http.StatusServiceUnavailable, detailedResponse.Attempts[0].StatusCode)
+}
@@ -101,12 +101,18 @@ func (e *HTTPProblem) GetConsoleOrderedMaps() *OrderedMaps {
 
 	if header, ok := e.getHeader("x-request-id"); ok {
 		orderedMaps.Add("request_id", header)
+	} else if e.Response.RequestID != "" {
+		orderedMaps.Add("request_id", e.Response.RequestID)
 	}
 
 	if header, ok := e.getHeader("x-correlation-id"); ok {
 		orderedMaps.Add("correlation_id", header)
 	}
 
+	if header, ok := e.getHeader(TRANSACTION_ID_HEADER); ok {
+		orderedMaps.Add("transaction_id", header)
+	}
+
 	return orderedMaps
 }
 
@@ -124,8 +130,13 @@ func (e *HTTPProblem) GetDebugOrderedMaps() *OrderedMaps {
 		printableResponse.Result = string(printableResponse.RawResult)
 	}
 	printableResponse.RawResult = nil
+	printableResponse.Attempts = nil
 	orderedMaps.Add("response", printableResponse)
 
+	if len(e.Response.Attempts) > 0 {
+		orderedMaps.Add("attempts", e.Response.Attempts)
+	}
+
 	var orderableCausedBy OrderableProblem
 	if errors.As(e.GetCausedBy(), &orderableCausedBy) {
 		orderedMaps.Add("caused_by", orderableCausedBy.GetDebugOrderedMaps().GetMaps())
@@ -366,6 +366,7 @@ func (authenticator *IamAssumeAuthenticator) setTokenData(tokenData *iamTokenDat
 func (authenticator *IamAssumeAuthenticator) GetToken() (string, error) {
 	if authenticator.getTokenData() == nil || !authenticator.getTokenData().isTokenValid() {
 		GetLogger().Debug("Performing synchronous token fetch...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_IAM_ASSUME, false)
 		// synchronously request the token
 		err := authenticator.synchronizedRequestToken()
 		if err != nil {
@@ -373,11 +374,13 @@ func (authenticator *IamAssumeAuthenticator) GetToken() (string, error) {
 		}
 	} else if authenticator.getTokenData().needsRefresh() {
 		GetLogger().Debug("Performing background asynchronous token fetch...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_IAM_ASSUME, true)
 		// If refresh needed, kick off a go routine in the background to get a new token
 		//nolint: errcheck
 		go authenticator.invokeRequestTokenData()
 	} else {
 		GetLogger().Debug("Using cached access token...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_IAM_ASSUME, true)
 	}
 
 	// return an error if the access token is not valid or was not fetched
@@ -422,6 +425,11 @@ func (authenticator *IamAssumeAuthenticator) invokeRequestTokenData() error {
 // RequestToken fetches a new access token from the token server and
 // returns the response structure.
 func (authenticator *IamAssumeAuthenticator) RequestToken() (*IamTokenServerResponse, error) {
+	return instrumentTokenRequest(AUTHTYPE_IAM_ASSUME, authenticator.requestToken)
+}
+
+// requestToken is the implementation of RequestToken.
+func (authenticator *IamAssumeAuthenticator) requestToken() (*IamTokenServerResponse, error) {
 	// Step 1: Obtain the user's IAM access token.
 	userAccessToken, err := authenticator.iamDelegate.GetToken()
 	if err != nil {
@@ -472,7 +480,7 @@ func (authenticator *IamAssumeAuthenticator) RequestToken() (*IamTokenServerResp
 	}
 
 	GetLogger().Debug("Invoking IAM 'get token (assume)' operation: %s", builder.URL)
-	resp, err := authenticator.getClient().Do(req)
+	resp, err := recordingClient(authenticator.getClient(), fmt.Sprintf(harTokenRequestComment, AUTHTYPE_IAM_ASSUME)).Do(req)
 	if err != nil {
 		err = SDKErrorf(err, "", "request-error", getComponentInfo())
 		return nil, err
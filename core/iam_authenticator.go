@@ -364,6 +364,7 @@ func (authenticator *IamAuthenticator) Validate() error {
 func (authenticator *IamAuthenticator) GetToken() (string, error) {
 	if authenticator.getTokenData() == nil || !authenticator.getTokenData().isTokenValid() {
 		GetLogger().Debug("Performing synchronous token fetch...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_IAM, false)
 		// synchronously request the token
 		err := authenticator.synchronizedRequestToken()
 		if err != nil {
@@ -371,11 +372,13 @@ func (authenticator *IamAuthenticator) GetToken() (string, error) {
 		}
 	} else if authenticator.getTokenData().needsRefresh() {
 		GetLogger().Debug("Performing background asynchronous token fetch...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_IAM, true)
 		// If refresh needed, kick off a go routine in the background to get a new token
 		//nolint: errcheck
 		go authenticator.invokeRequestTokenData()
 	} else {
 		GetLogger().Debug("Using cached access token...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_IAM, true)
 	}
 
 	// return an error if the access token is not valid or was not fetched
@@ -421,6 +424,11 @@ func (authenticator *IamAuthenticator) invokeRequestTokenData() error {
 
 // RequestToken fetches a new access token from the token server.
 func (authenticator *IamAuthenticator) RequestToken() (*IamTokenServerResponse, error) {
+	return instrumentTokenRequest(AUTHTYPE_IAM, authenticator.requestToken)
+}
+
+// requestToken is the implementation of RequestToken.
+func (authenticator *IamAuthenticator) requestToken() (*IamTokenServerResponse, error) {
 	builder := NewRequestBuilder(POST)
 	_, err := builder.ResolveRequestURL(authenticator.url(), iamAuthOperationPathGetToken, nil)
 	if err != nil {
@@ -480,7 +488,7 @@ func (authenticator *IamAuthenticator) RequestToken() (*IamTokenServerResponse,
 	}
 
 	GetLogger().Debug("Invoking IAM 'get token' operation: %s", builder.URL)
-	resp, err := authenticator.client().Do(req)
+	resp, err := recordingClient(authenticator.client(), fmt.Sprintf(harTokenRequestComment, AUTHTYPE_IAM)).Do(req)
 	if err != nil {
 		err = SDKErrorf(err, "", "request-error", getComponentInfo())
 		return nil, err
@@ -0,0 +1,71 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"net/http"
+)
+
+const (
+	// DEFAULT_IDEMPOTENCY_KEY_HEADER is the name of the header that carries
+	// a request's idempotency key, unless configured otherwise.
+	DEFAULT_IDEMPOTENCY_KEY_HEADER = "Idempotency-Key"
+)
+
+// IsIdempotentMethod returns true if requests with the specified HTTP method are
+// idempotent (i.e. sending the same request more than once has the same effect
+// as sending it once), as defined by RFC 9110. POST and PATCH are the only common
+// methods that are not idempotent.
+func IsIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPatch, http.MethodConnect:
+		return false
+	}
+	return true
+}
+
+// NewIdempotencyKey returns a new, randomly-generated idempotency key.
+func NewIdempotencyKey() string {
+	return newUUID()
+}
+
+// idempotencyKeyHeader returns the name of the header that carries the idempotency key
+// of the requests processed by the service.
+func (service *BaseService) idempotencyKeyHeader() string {
+	if service.Options.IdempotencyKeyHeader != "" {
+		return service.Options.IdempotencyKeyHeader
+	}
+	return DEFAULT_IDEMPOTENCY_KEY_HEADER
+}
+
+// addIdempotencyKey adds a new idempotency key to "req" if idempotency keys are enabled,
+// the request's method is not idempotent and the request doesn't already carry a key.
+// Since the key is added before the request is sent, it is re-used by each retry attempt.
+func (service *BaseService) addIdempotencyKey(req *http.Request) {
+	if !service.Options.EnableIdempotencyKeys || IsIdempotentMethod(req.Method) {
+		return
+	}
+	headerName := service.idempotencyKeyHeader()
+	if req.Header.Get(headerName) == "" {
+		req.Header.Set(headerName, NewIdempotencyKey())
+	}
+}
+
+// isRetryForbidden returns true if "req" must not be retried because the service
+// only retries requests with a non-idempotent method if they carry an idempotency key.
+func (service *BaseService) isRetryForbidden(req *http.Request) bool {
+	return service.Options.RequireIdempotencyKeyForRetries && !IsIdempotentMethod(req.Method) &&
+		req.Header.Get(service.idempotencyKeyHeader()) == ""
+}
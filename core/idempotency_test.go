@@ -0,0 +1,176 @@
+//go:build all || fast || basesvc || retries
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// startIdempotencyTestServer starts a server that fails the first "failures" requests
+// with a 503 status code, recording the idempotency key header of each request.
+func startIdempotencyTestServer(headerName string, failures int) (*httptest.Server, *[]string) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(headerName))
+		if len(keys) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", APPLICATION_JSON)
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"name": "instance-1"}`)
+	}))
+	return server, &keys
+}
+
+// newInstanceRequestBuilder returns a builder for a request to create or update
+// an instance with "method", which has the specified headers.
+func newInstanceRequestBuilder(method string, headers map[string]string) *RequestBuilder {
+	builder := NewRequestBuilder(method)
+	for name, value := range headers {
+		builder.AddHeader(name, value)
+	}
+	if method != GET {
+		_, _ = builder.SetBodyContentJSON(map[string]string{"name": "instance-1"})
+	}
+	return builder
+}
+
+func TestIdempotencyKeyReusedAcrossRetries(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server, keys := startIdempotencyTestServer(DEFAULT_IDEMPOTENCY_KEY_HEADER, 2)
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	service.EnableRetries(3, 10*time.Millisecond)
+	assert.False(t, service.GetEnableIdempotencyKeys())
+	service.SetEnableIdempotencyKeys(true)
+	assert.True(t, service.GetEnableIdempotencyKeys())
+
+	detailedResponse, err := invokeTestRequest(t, service, newInstanceRequestBuilder(POST, nil), "/instances", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, detailedResponse.StatusCode)
+
+	assert.Len(t, *keys, 3)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), (*keys)[0])
+	assert.Equal(t, (*keys)[0], (*keys)[1])
+	assert.Equal(t, (*keys)[0], (*keys)[2])
+
+	// Each request gets its own key.
+	_, err = invokeTestRequest(t, service, newInstanceRequestBuilder(POST, nil), "/instances", nil)
+	assert.Nil(t, err)
+	assert.NotEqual(t, (*keys)[0], (*keys)[3])
+}
+
+func TestIdempotencyKeyCustomHeader(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server, keys := startIdempotencyTestServer("X-Request-Token", 0)
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	service.EnableRetries(3, 10*time.Millisecond)
+	service.SetEnableIdempotencyKeys(true)
+	service.SetIdempotencyKeyHeader("X-Request-Token")
+	assert.Equal(t, "X-Request-Token", service.GetIdempotencyKeyHeader())
+
+	// A key supplied by the caller is left as-is.
+	_, err := invokeTestRequest(t, service, newInstanceRequestBuilder(PATCH, map[string]string{"X-Request-Token": "my-key"}), "/instances", nil)
+	assert.Nil(t, err)
+	_, err = invokeTestRequest(t, service, newInstanceRequestBuilder(PATCH, nil), "/instances", nil)
+	assert.Nil(t, err)
+
+	// Idempotent methods don't get a key.
+	_, err = invokeTestRequest(t, service, newInstanceRequestBuilder(PUT, nil), "/instances", nil)
+	assert.Nil(t, err)
+	_, err = invokeTestRequest(t, service, newInstanceRequestBuilder(GET, nil), "/instances", nil)
+	assert.Nil(t, err)
+
+	assert.Len(t, *keys, 4)
+	assert.Equal(t, "my-key", (*keys)[0])
+	assert.NotEmpty(t, (*keys)[1])
+	assert.Empty(t, (*keys)[2])
+	assert.Empty(t, (*keys)[3])
+}
+
+func TestRequireIdempotencyKeyForRetries(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+
+	// Without a key, a POST request is not retried.
+	server, keys := startIdempotencyTestServer(DEFAULT_IDEMPOTENCY_KEY_HEADER, 1)
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	service.EnableRetries(3, 10*time.Millisecond)
+	service.SetRequireIdempotencyKeyForRetries(true)
+	assert.True(t, service.GetRequireIdempotencyKeyForRetries())
+
+	detailedResponse, err := invokeTestRequest(t, service, newInstanceRequestBuilder(POST, nil), "/instances", nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, detailedResponse.StatusCode)
+	assert.Len(t, *keys, 1)
+	server.Close()
+
+	// Idempotent methods are still retried.
+	server, keys = startIdempotencyTestServer(DEFAULT_IDEMPOTENCY_KEY_HEADER, 1)
+	assert.Nil(t, service.SetServiceURL(server.URL))
+	_, err = invokeTestRequest(t, service, newInstanceRequestBuilder(PUT, nil), "/instances", nil)
+	assert.Nil(t, err)
+	assert.Len(t, *keys, 2)
+	server.Close()
+
+	// With a key, the POST request is retried.
+	server, keys = startIdempotencyTestServer(DEFAULT_IDEMPOTENCY_KEY_HEADER, 1)
+	defer server.Close()
+	assert.Nil(t, service.SetServiceURL(server.URL))
+	service.SetEnableIdempotencyKeys(true)
+	_, err = invokeTestRequest(t, service, newInstanceRequestBuilder(POST, nil), "/instances", nil)
+	assert.Nil(t, err)
+	assert.Len(t, *keys, 2)
+	assert.Equal(t, (*keys)[0], (*keys)[1])
+}
+
+func TestRetryPolicyRequestState(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests}
+
+	retry, err := IBMCloudSDKRetryPolicy(context.Background(), resp, nil)
+	assert.True(t, retry)
+	assert.Nil(t, err)
+
+	ctx := withRequestState(context.Background(), &requestState{noRetry: true})
+	retry, err = IBMCloudSDKRetryPolicy(ctx, resp, nil)
+	assert.False(t, retry)
+	assert.Nil(t, err)
+
+	retry, _ = IBMCloudSDKRetryPolicy(ctx, nil, errors.New("connection reset"))
+	assert.False(t, retry)
+}
+
+func TestIsIdempotentMethod(t *testing.T) {
+	assert.True(t, IsIdempotentMethod(GET))
+	assert.True(t, IsIdempotentMethod(HEAD))
+	assert.True(t, IsIdempotentMethod(PUT))
+	assert.True(t, IsIdempotentMethod(DELETE))
+	assert.False(t, IsIdempotentMethod(POST))
+	assert.False(t, IsIdempotentMethod(PATCH))
+}
@@ -0,0 +1,117 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"net/http"
+)
+
+// Interceptor is the interface implemented by types that want to observe or modify
+// the requests and responses processed by BaseService.Request().
+// Interceptors are registered on a service via the ServiceOptions.Interceptors field
+// or the BaseService.AddInterceptor() method, and form a chain that wraps the
+// sending of each request:
+//
+//  1. InterceptRequest() is invoked on each interceptor, in registration order, after the
+//     default headers, User-Agent header and authentication information have been added to the
+//     request and just before the request is sent.
+//  2. InterceptResponse() is invoked on each interceptor, in reverse registration order,
+//     with the raw response immediately after it has been received and before it is processed.
+//  3. InterceptDetailedResponse() is invoked on each interceptor, in reverse registration order,
+//     after the response has been processed (successfully or not).
+type Interceptor interface {
+	// InterceptRequest can inspect or modify "req" before it is sent.
+	// If a non-nil error is returned, the request is not sent and the error is returned
+	// to the caller of BaseService.Request().
+	InterceptRequest(req *http.Request) error
+
+	// InterceptResponse can inspect or modify "resp" (e.g. its headers or body) before it is
+	// processed by the service.  If a non-nil error is returned, the response is not processed
+	// and the error is returned to the caller of BaseService.Request().
+	InterceptResponse(req *http.Request, resp *http.Response) error
+
+	// InterceptDetailedResponse can inspect or modify the DetailedResponse produced for "req".
+	// "detailedResponse" may be nil if no response was received, and "err" is the error (if any)
+	// that will be returned to the caller of BaseService.Request().
+	// The error returned by this method replaces "err", so implementations that do not
+	// want to alter the outcome of the request should simply return "err".
+	// This method is invoked only on the interceptors whose InterceptRequest() method was invoked
+	// successfully.
+	InterceptDetailedResponse(req *http.Request, detailedResponse *DetailedResponse, err error) error
+}
+
+// InterceptorFuncs is a convenience implementation of the Interceptor interface that
+// allows an interceptor to be constructed from individual functions.
+// Any of the functions may be nil, in which case the corresponding
+// step of the interceptor is a no-op.
+type InterceptorFuncs struct {
+	RequestFunc          func(req *http.Request) error
+	ResponseFunc         func(req *http.Request, resp *http.Response) error
+	DetailedResponseFunc func(req *http.Request, detailedResponse *DetailedResponse, err error) error
+}
+
+// InterceptRequest invokes the RequestFunc function, if set.
+func (i *InterceptorFuncs) InterceptRequest(req *http.Request) error {
+	if i.RequestFunc != nil {
+		return i.RequestFunc(req)
+	}
+	return nil
+}
+
+// InterceptResponse invokes the ResponseFunc function, if set.
+func (i *InterceptorFuncs) InterceptResponse(req *http.Request, resp *http.Response) error {
+	if i.ResponseFunc != nil {
+		return i.ResponseFunc(req, resp)
+	}
+	return nil
+}
+
+// InterceptDetailedResponse invokes the DetailedResponseFunc function, if set.
+func (i *InterceptorFuncs) InterceptDetailedResponse(req *http.Request, detailedResponse *DetailedResponse, err error) error {
+	if i.DetailedResponseFunc != nil {
+		return i.DetailedResponseFunc(req, detailedResponse, err)
+	}
+	return err
+}
+
+// interceptRequest invokes InterceptRequest() on each interceptor in "chain" and returns
+// the number of interceptors that were invoked successfully.
+func interceptRequest(chain []Interceptor, req *http.Request) (int, error) {
+	for i, interceptor := range chain {
+		if err := interceptor.InterceptRequest(req); err != nil {
+			return i, SDKErrorf(err, "", "request-interceptor-error", getComponentInfo())
+		}
+	}
+	return len(chain), nil
+}
+
+// interceptResponse invokes InterceptResponse() on each interceptor in "chain", in reverse order.
+func interceptResponse(chain []Interceptor, req *http.Request, resp *http.Response) error {
+	for i := len(chain) - 1; i >= 0; i-- {
+		if err := chain[i].InterceptResponse(req, resp); err != nil {
+			return SDKErrorf(err, "", "response-interceptor-error", getComponentInfo())
+		}
+	}
+	return nil
+}
+
+// interceptDetailedResponse invokes InterceptDetailedResponse() on each interceptor in "chain",
+// in reverse order, and returns the resulting error.
+func interceptDetailedResponse(chain []Interceptor, req *http.Request, detailedResponse *DetailedResponse, err error) error {
+	for i := len(chain) - 1; i >= 0; i-- {
+		err = chain[i].InterceptDetailedResponse(req, detailedResponse, err)
+	}
+	return err
+}
@@ -0,0 +1,209 @@
+//go:build all || fast || basesvc
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingInterceptor appends a description of each invocation to "events".
+func recordingInterceptor(name string, events *[]string) Interceptor {
+	return &InterceptorFuncs{
+		RequestFunc: func(req *http.Request) error {
+			*events = append(*events, name+":request")
+			req.Header.Set("X-"+name, "true")
+			return nil
+		},
+		ResponseFunc: func(req *http.Request, resp *http.Response) error {
+			*events = append(*events, name+":response")
+			return nil
+		},
+		DetailedResponseFunc: func(req *http.Request, detailedResponse *DetailedResponse, err error) error {
+			*events = append(*events, name+":detailed-response")
+			return err
+		},
+	}
+}
+
+func TestInterceptorChainOrder(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.Header.Get("X-first"))
+		assert.Equal(t, "true", r.Header.Get("X-second"))
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name": "wonder woman"}`)
+	}))
+	defer server.Close()
+
+	var events []string
+	authenticator, _ := NewBearerTokenAuthenticator("token")
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: authenticator,
+		Interceptors:  []Interceptor{recordingInterceptor("first", &events)},
+	})
+	assert.Nil(t, err)
+	service.AddInterceptor(recordingInterceptor("second", &events))
+	assert.Len(t, service.GetInterceptors(), 2)
+
+	builder := NewRequestBuilder(GET)
+	_, err = builder.ResolveRequestURL(server.URL, "", nil)
+	assert.Nil(t, err)
+	req, _ := builder.Build()
+
+	var foo *Foo
+	detailedResponse, err := service.Request(req, &foo)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, detailedResponse.StatusCode)
+	assert.Equal(t, "wonder woman", *foo.Name)
+	assert.Equal(t, []string{
+		"first:request",
+		"second:request",
+		"second:response",
+		"first:response",
+		"second:detailed-response",
+		"first:detailed-response",
+	}, events)
+}
+
+func TestInterceptorAddDoesNotAffectClone(t *testing.T) {
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           "https://myservice.ibm.com/api/v1",
+		Authenticator: &NoAuthAuthenticator{},
+		Interceptors:  []Interceptor{&InterceptorFuncs{}},
+	})
+	assert.Nil(t, err)
+
+	clone := service.Clone()
+	clone.AddInterceptor(&InterceptorFuncs{})
+	assert.Len(t, service.GetInterceptors(), 1)
+	assert.Len(t, clone.GetInterceptors(), 2)
+}
+
+func TestInterceptorRequestError(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	requestSent := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestSent = true
+	}))
+	defer server.Close()
+
+	var events []string
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+	service.AddInterceptor(recordingInterceptor("first", &events))
+	service.AddInterceptor(&InterceptorFuncs{
+		RequestFunc: func(req *http.Request) error {
+			return errors.New("missing tenant")
+		},
+		DetailedResponseFunc: func(req *http.Request, detailedResponse *DetailedResponse, err error) error {
+			events = append(events, "failing:detailed-response")
+			return err
+		},
+	})
+
+	req, _ := NewRequestBuilder(GET).ResolveRequestURL(server.URL, "", nil)
+	httpReq, _ := req.Build()
+
+	detailedResponse, err := service.Request(httpReq, nil)
+	assert.NotNil(t, err)
+	assert.Nil(t, detailedResponse)
+	assert.Contains(t, err.Error(), "missing tenant")
+	assert.False(t, requestSent)
+
+	// Only the interceptor that was invoked successfully should see the outcome.
+	assert.Equal(t, []string{"first:request", "first:detailed-response"}, events)
+}
+
+func TestInterceptorResponseError(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"name": "wonder woman"}`)
+	}))
+	defer server.Close()
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+	service.AddInterceptor(&InterceptorFuncs{
+		ResponseFunc: func(req *http.Request, resp *http.Response) error {
+			return errors.New("unexpected response")
+		},
+	})
+
+	req, _ := NewRequestBuilder(GET).ResolveRequestURL(server.URL, "", nil)
+	httpReq, _ := req.Build()
+
+	var foo *Foo
+	detailedResponse, err := service.Request(httpReq, &foo)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unexpected response")
+	assert.NotNil(t, detailedResponse)
+	assert.Equal(t, http.StatusOK, detailedResponse.StatusCode)
+	assert.Nil(t, foo)
+}
+
+func TestInterceptorDetailedResponse(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error": "not found"}`)
+	}))
+	defer server.Close()
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+
+	// Post-process the response by converting a 404 into a successful, empty result.
+	service.AddInterceptor(&InterceptorFuncs{
+		DetailedResponseFunc: func(req *http.Request, detailedResponse *DetailedResponse, err error) error {
+			assert.NotNil(t, err)
+			if detailedResponse != nil && detailedResponse.StatusCode == http.StatusNotFound {
+				detailedResponse.Result = nil
+				return nil
+			}
+			return err
+		},
+	})
+
+	req, _ := NewRequestBuilder(GET).ResolveRequestURL(server.URL, "", nil)
+	httpReq, _ := req.Build()
+
+	var foo *Foo
+	detailedResponse, err := service.Request(httpReq, &foo)
+	assert.Nil(t, err)
+	assert.NotNil(t, detailedResponse)
+	assert.Equal(t, http.StatusNotFound, detailedResponse.StatusCode)
+	assert.Nil(t, detailedResponse.Result)
+}
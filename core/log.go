@@ -59,7 +59,9 @@ type SDKLoggerImpl struct {
 
 	// The current log level configured in this logger.
 	// Only messages with a log level that is <= 'logLevel' will be displayed.
-	logLevel LogLevel
+	// It is guarded by 'levelMutex', as it may be changed while messages are logged.
+	logLevel   LogLevel
+	levelMutex sync.RWMutex
 
 	// The underlying log.Logger instances used to log info/warn/debug messages.
 	infoLogger *log.Logger
@@ -74,18 +76,22 @@ type SDKLoggerImpl struct {
 
 // SetLogLevel sets level to be the current logging level
 func (l *SDKLoggerImpl) SetLogLevel(level LogLevel) {
+	l.levelMutex.Lock()
+	defer l.levelMutex.Unlock()
 	l.logLevel = level
 }
 
 // GetLogLevel sets level to be the current logging level
 func (l *SDKLoggerImpl) GetLogLevel() LogLevel {
+	l.levelMutex.RLock()
+	defer l.levelMutex.RUnlock()
 	return l.logLevel
 }
 
 // IsLogLevelEnabled returns true iff the logger's current logging level
 // indicates that 'level' is enabled.
 func (l *SDKLoggerImpl) IsLogLevelEnabled(level LogLevel) bool {
-	return l.logLevel >= level
+	return l.GetLogLevel() >= level
 }
 
 // infoLog returns the underlying log.Logger instance used for info/warn/debug logging.
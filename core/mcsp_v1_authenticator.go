@@ -230,6 +230,7 @@ func (authenticator *MCSPAuthenticator) Validate() error {
 func (authenticator *MCSPAuthenticator) GetToken() (string, error) {
 	if authenticator.getTokenData() == nil || !authenticator.getTokenData().isTokenValid() {
 		GetLogger().Debug("Performing synchronous token fetch...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_MCSP, false)
 		// synchronously request the token
 		err := authenticator.synchronizedRequestToken()
 		if err != nil {
@@ -237,11 +238,13 @@ func (authenticator *MCSPAuthenticator) GetToken() (string, error) {
 		}
 	} else if authenticator.getTokenData().needsRefresh() {
 		GetLogger().Debug("Performing background asynchronous token fetch...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_MCSP, true)
 		// If refresh needed, kick off a go routine in the background to get a new token.
 		//nolint: errcheck
 		go authenticator.invokeRequestTokenData()
 	} else {
 		GetLogger().Debug("Using cached access token...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_MCSP, true)
 	}
 
 	// return an error if the access token is not valid or was not fetched
@@ -288,6 +291,11 @@ func (authenticator *MCSPAuthenticator) invokeRequestTokenData() error {
 
 // RequestToken fetches a new access token from the token server.
 func (authenticator *MCSPAuthenticator) RequestToken() (*MCSPTokenServerResponse, error) {
+	return instrumentTokenRequest(AUTHTYPE_MCSP, authenticator.requestToken)
+}
+
+// requestToken is the implementation of RequestToken.
+func (authenticator *MCSPAuthenticator) requestToken() (*MCSPTokenServerResponse, error) {
 	builder := NewRequestBuilder(POST)
 	_, err := builder.ResolveRequestURL(authenticator.URL, mcspAuthOperationPath, nil)
 	if err != nil {
@@ -322,7 +330,7 @@ func (authenticator *MCSPAuthenticator) RequestToken() (*MCSPTokenServerResponse
 	}
 
 	GetLogger().Debug("Invoking MCSP 'get token' operation: %s", builder.URL)
-	resp, err := authenticator.client().Do(req)
+	resp, err := recordingClient(authenticator.client(), fmt.Sprintf(harTokenRequestComment, AUTHTYPE_MCSP)).Do(req)
 	if err != nil {
 		err = SDKErrorf(err, "", "request-error", getComponentInfo())
 		return nil, err
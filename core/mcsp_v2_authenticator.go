@@ -387,6 +387,7 @@ func (authenticator *MCSPV2Authenticator) setTokenData(tokenData *mcspv2TokenDat
 func (authenticator *MCSPV2Authenticator) GetToken() (string, error) {
 	if authenticator.getTokenData() == nil || !authenticator.getTokenData().isTokenValid() {
 		GetLogger().Debug("Performing synchronous token fetch...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_MCSPV2, false)
 		// synchronously request the token
 		err := authenticator.synchronizedRequestToken()
 		if err != nil {
@@ -394,11 +395,13 @@ func (authenticator *MCSPV2Authenticator) GetToken() (string, error) {
 		}
 	} else if authenticator.getTokenData().needsRefresh() {
 		GetLogger().Debug("Performing background asynchronous token fetch...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_MCSPV2, true)
 		// If refresh needed, kick off a go routine in the background to get a new token.
 		//nolint: errcheck
 		go authenticator.invokeRequestTokenData()
 	} else {
 		GetLogger().Debug("Using cached access token...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_MCSPV2, true)
 	}
 
 	// return an error if the access token is not valid or was not fetched
@@ -445,6 +448,11 @@ func (authenticator *MCSPV2Authenticator) invokeRequestTokenData() error {
 
 // RequestToken fetches a new access token from the token server.
 func (authenticator *MCSPV2Authenticator) RequestToken() (*MCSPV2TokenServerResponse, error) {
+	return instrumentTokenRequest(AUTHTYPE_MCSPV2, authenticator.requestToken)
+}
+
+// requestToken is the implementation of RequestToken.
+func (authenticator *MCSPV2Authenticator) requestToken() (*MCSPV2TokenServerResponse, error) {
 	builder := NewRequestBuilder(POST)
 	pathParams := map[string]string{
 		"scopeCollectionType": authenticator.ScopeCollectionType,
@@ -496,7 +504,7 @@ func (authenticator *MCSPV2Authenticator) RequestToken() (*MCSPV2TokenServerResp
 	}
 
 	GetLogger().Debug("Invoking MCSP 'get token' operation: %s", builder.URL)
-	resp, err := authenticator.client().Do(req)
+	resp, err := recordingClient(authenticator.client(), fmt.Sprintf(harTokenRequestComment, AUTHTYPE_MCSPV2)).Do(req)
 	if err != nil {
 		err = SDKErrorf(err, "", "request-error", getComponentInfo())
 		return nil, err
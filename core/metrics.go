@@ -0,0 +1,332 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// headerNameSDKAnalytics is the name of the header added by generated SDKs to identify
+	// the service and operation of a request (e.g. "service_name=x;service_version=V1;operation_id=y").
+	headerNameSDKAnalytics = "X-IBMCloud-SDK-Analytics"
+)
+
+// RequestMetricLabels identifies the requests whose metrics are aggregated together.
+type RequestMetricLabels struct {
+	// The name of the service and the ID of the operation, as identified by the
+	// "X-IBMCloud-SDK-Analytics" header added by generated SDKs ("" if unknown).
+	Service   string
+	Operation string
+
+	// The method of the request.
+	Method string
+
+	// The status code of the response, or 0 if no response was received.
+	StatusCode int
+}
+
+// Metrics is the interface used by the Go core to record metrics about the requests processed
+// by services and the tokens obtained by authenticators. The Metrics used by the Go core is set
+// with SetMetrics. NewInMemoryMetrics returns an implementation that can be exposed to Prometheus.
+type Metrics interface {
+	// ObserveRequest records a request processed by a service, which took "duration"
+	// and was retried "retries" times.
+	ObserveRequest(labels RequestMetricLabels, duration time.Duration, retries int)
+
+	// ObserveTokenRequest records a token request made by an authenticator of type "authType",
+	// which took "duration" and failed with "err" (if not nil).
+	ObserveTokenRequest(authType string, duration time.Duration, err error)
+
+	// ObserveTokenCache records whether an authenticator of type "authType" used its cached token
+	// ("hit" is true) or had to wait for a new token to be obtained ("hit" is false).
+	ObserveTokenCache(authType string, hit bool)
+}
+
+// noopMetrics is the default Metrics, which doesn't record anything.
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveRequest(RequestMetricLabels, time.Duration, int) {}
+func (noopMetrics) ObserveTokenRequest(string, time.Duration, error)       {}
+func (noopMetrics) ObserveTokenCache(string, bool)                         {}
+
+// sdkMetrics holds the Metrics implementation used by the Go core library.
+var sdkMetrics Metrics = noopMetrics{}
+
+// SetMetrics sets the specified Metrics instance as the metrics to be recorded by the Go core library
+// (nil to disable metrics).
+func SetMetrics(metrics Metrics) {
+	if metrics == nil {
+		metrics = noopMetrics{}
+	}
+	sdkMetrics = metrics
+}
+
+// GetMetrics returns the Metrics instance currently used by the Go core.
+func GetMetrics() Metrics {
+	return sdkMetrics
+}
+
+// getRequestMetricLabels returns the labels of the metrics of "req".
+func getRequestMetricLabels(req *http.Request, detailedResponse *DetailedResponse) RequestMetricLabels {
+	labels := RequestMetricLabels{Method: req.Method}
+	for _, field := range strings.Split(getRequestHeader(req, headerNameSDKAnalytics), ";") {
+		name, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+		switch name {
+		case "service_name":
+			labels.Service = value
+		case "operation_id":
+			labels.Operation = value
+		}
+	}
+	if detailedResponse != nil {
+		labels.StatusCode = detailedResponse.StatusCode
+	}
+	return labels
+}
+
+// instrumentTokenRequest invokes "requestToken" within a span that describes a token request
+// made by an authenticator of type "authType", and records the token request's metrics.
+func instrumentTokenRequest[T any](authType string, requestToken func() (T, error)) (T, error) {
+	start := time.Now()
+	tokenResponse, err := traceTokenRequest(authType, requestToken)
+	GetMetrics().ObserveTokenRequest(authType, time.Since(start), err)
+	return tokenResponse, err
+}
+
+// The names of the metrics recorded by InMemoryMetrics.
+const (
+	METRIC_REQUEST_DURATION       = "ibm_sdk_request_duration_seconds"
+	METRIC_REQUEST_RETRIES        = "ibm_sdk_request_retries_total"
+	METRIC_TOKEN_REQUEST_DURATION = "ibm_sdk_token_request_duration_seconds"
+	METRIC_TOKEN_REQUEST_FAILURES = "ibm_sdk_token_request_failures_total"
+	METRIC_TOKEN_CACHE_REQUESTS   = "ibm_sdk_token_cache_requests_total"
+)
+
+// DefaultMetricsBuckets are the default upper bounds (in seconds) of the buckets
+// of the latency histograms recorded by InMemoryMetrics.
+var DefaultMetricsBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// InMemoryMetrics is a Metrics implementation that aggregates metrics in memory,
+// and renders them in the Prometheus text exposition format (see WritePrometheus).
+// It records the following metrics:
+//   - ibm_sdk_request_duration_seconds: a histogram of the latency of requests,
+//     by service, operation, method and status code
+//   - ibm_sdk_request_retries_total: the number of retries of requests, by service, operation and method
+//   - ibm_sdk_token_request_duration_seconds: a histogram of the latency of token requests, by auth type
+//   - ibm_sdk_token_request_failures_total: the number of failed token requests, by auth type
+//   - ibm_sdk_token_cache_requests_total: the number of tokens requested from authenticators,
+//     by auth type and result ("hit" if the cached token was used, "miss" otherwise)
+type InMemoryMetrics struct {
+	mutex   sync.Mutex
+	buckets []float64
+	metrics map[string]map[string]*metricSeries
+}
+
+// metricSeries is the value of a counter, or of a histogram if it has buckets.
+type metricSeries struct {
+	value   float64
+	buckets []uint64
+	count   uint64
+}
+
+// NewInMemoryMetrics returns a new InMemoryMetrics whose latency histograms have buckets with
+// the specified upper bounds, in seconds (DefaultMetricsBuckets if none are specified).
+func NewInMemoryMetrics(buckets ...float64) *InMemoryMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultMetricsBuckets
+	}
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+	return &InMemoryMetrics{
+		buckets: buckets,
+		metrics: make(map[string]map[string]*metricSeries),
+	}
+}
+
+// ObserveRequest records a request processed by a service.
+func (metrics *InMemoryMetrics) ObserveRequest(labels RequestMetricLabels, duration time.Duration, retries int) {
+	status := "error"
+	if labels.StatusCode != 0 {
+		status = strconv.Itoa(labels.StatusCode)
+	}
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	metrics.observe(METRIC_REQUEST_DURATION, duration, "service", labels.Service, "operation", labels.Operation,
+		"method", labels.Method, "status", status)
+	metrics.add(METRIC_REQUEST_RETRIES, float64(retries), "service", labels.Service, "operation", labels.Operation,
+		"method", labels.Method)
+}
+
+// ObserveTokenRequest records a token request made by an authenticator.
+func (metrics *InMemoryMetrics) ObserveTokenRequest(authType string, duration time.Duration, err error) {
+	failures := 0.0
+	if err != nil {
+		failures = 1
+	}
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	metrics.observe(METRIC_TOKEN_REQUEST_DURATION, duration, "auth_type", authType)
+	metrics.add(METRIC_TOKEN_REQUEST_FAILURES, failures, "auth_type", authType)
+}
+
+// ObserveTokenCache records whether an authenticator used its cached token.
+func (metrics *InMemoryMetrics) ObserveTokenCache(authType string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	metrics.add(METRIC_TOKEN_CACHE_REQUESTS, 1, "auth_type", authType, "result", result)
+}
+
+// TokenCacheHitRate returns the fraction of the tokens requested from authenticators of type "authType"
+// that were served from their cache, or 0 if no tokens have been requested.
+func (metrics *InMemoryMetrics) TokenCacheHitRate(authType string) float64 {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+	hits := metrics.value(METRIC_TOKEN_CACHE_REQUESTS, "auth_type", authType, "result", "hit")
+	misses := metrics.value(METRIC_TOKEN_CACHE_REQUESTS, "auth_type", authType, "result", "miss")
+	if hits+misses == 0 {
+		return 0
+	}
+	return hits / (hits + misses)
+}
+
+// value returns the value of a counter (0 if it doesn't exist).
+func (metrics *InMemoryMetrics) value(name string, labels ...string) float64 {
+	if series := metrics.metrics[name][formatMetricLabels(labels...)]; series != nil {
+		return series.value
+	}
+	return 0
+}
+
+// series returns the series of metric "name" with the specified label names and values,
+// creating it if needed.
+func (metrics *InMemoryMetrics) series(name string, labels []string) *metricSeries {
+	key := formatMetricLabels(labels...)
+	if metrics.metrics[name] == nil {
+		metrics.metrics[name] = make(map[string]*metricSeries)
+	}
+	series := metrics.metrics[name][key]
+	if series == nil {
+		series = &metricSeries{}
+		metrics.metrics[name][key] = series
+	}
+	return series
+}
+
+// add adds "value" to a counter.
+func (metrics *InMemoryMetrics) add(name string, value float64, labels ...string) {
+	metrics.series(name, labels).value += value
+}
+
+// observe adds "duration" to a histogram.
+func (metrics *InMemoryMetrics) observe(name string, duration time.Duration, labels ...string) {
+	series := metrics.series(name, labels)
+	if series.buckets == nil {
+		series.buckets = make([]uint64, len(metrics.buckets))
+	}
+	seconds := duration.Seconds()
+	for i, bound := range metrics.buckets {
+		if seconds <= bound {
+			series.buckets[i]++
+		}
+	}
+	series.value += seconds
+	series.count++
+}
+
+// WritePrometheus writes the metrics to "w" in the Prometheus text exposition format.
+func (metrics *InMemoryMetrics) WritePrometheus(w io.Writer) error {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	help := []struct{ name, kind, text string }{
+		{METRIC_REQUEST_DURATION, "histogram", "The latency of the requests processed by services."},
+		{METRIC_REQUEST_RETRIES, "counter", "The number of retries of the requests processed by services."},
+		{METRIC_TOKEN_REQUEST_DURATION, "histogram", "The latency of the token requests made by authenticators."},
+		{METRIC_TOKEN_REQUEST_FAILURES, "counter", "The number of failed token requests made by authenticators."},
+		{METRIC_TOKEN_CACHE_REQUESTS, "counter", "The number of tokens requested from authenticators, by cache result."},
+	}
+
+	buf := bufio.NewWriter(w)
+	for _, metric := range help {
+		allSeries := metrics.metrics[metric.name]
+		if len(allSeries) == 0 {
+			continue
+		}
+		fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.text, metric.name, metric.kind)
+
+		keys := make([]string, 0, len(allSeries))
+		for key := range allSeries {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			series := allSeries[key]
+			if metric.kind == "counter" {
+				fmt.Fprintf(buf, "%s{%s} %s\n", metric.name, key, formatMetricValue(series.value))
+				continue
+			}
+			for i, bound := range metrics.buckets {
+				fmt.Fprintf(buf, "%s_bucket{%s,le=\"%s\"} %d\n", metric.name, key, formatMetricValue(bound), series.buckets[i])
+			}
+			fmt.Fprintf(buf, "%s_bucket{%s,le=\"+Inf\"} %d\n", metric.name, key, series.count)
+			fmt.Fprintf(buf, "%s_sum{%s} %s\n", metric.name, key, formatMetricValue(series.value))
+			fmt.Fprintf(buf, "%s_count{%s} %d\n", metric.name, key, series.count)
+		}
+	}
+	return buf.Flush()
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format,
+// so that an InMemoryMetrics can be registered as the handler of a "/metrics" endpoint.
+func (metrics *InMemoryMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set(CONTENT_TYPE, "text/plain; version=0.0.4; charset=utf-8")
+	_ = metrics.WritePrometheus(w)
+}
+
+// formatMetricLabels formats pairs of label names and values as Prometheus labels (e.g. `a="1",b="2"`).
+func formatMetricLabels(labels ...string) string {
+	pairs := make([]string, 0, len(labels)/2)
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=\"%s\"", labels[i], escapeMetricLabelValue(labels[i+1])))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// escapeMetricLabelValue escapes the backslashes, double quotes and line feeds in "value".
+func escapeMetricLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// formatMetricValue formats a sample value or bucket bound.
+func formatMetricValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
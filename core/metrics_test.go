@@ -0,0 +1,167 @@
+//go:build all || fast || basesvc || retries
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemoryMetricsPrometheus(t *testing.T) {
+	metrics := NewInMemoryMetrics(1, 0.1)
+	labels := RequestMetricLabels{Service: "my_service", Operation: "list_instances", Method: GET, StatusCode: 200}
+	metrics.ObserveRequest(labels, 50*time.Millisecond, 0)
+	metrics.ObserveRequest(labels, 500*time.Millisecond, 2)
+	metrics.ObserveRequest(RequestMetricLabels{Service: `a"b\c`, Method: POST}, 2*time.Second, 0)
+	metrics.ObserveTokenRequest(AUTHTYPE_IAM, 250*time.Millisecond, nil)
+	metrics.ObserveTokenRequest(AUTHTYPE_IAM, 250*time.Millisecond, errors.New("failed"))
+	metrics.ObserveTokenCache(AUTHTYPE_IAM, false)
+	metrics.ObserveTokenCache(AUTHTYPE_IAM, true)
+	metrics.ObserveTokenCache(AUTHTYPE_IAM, true)
+	metrics.ObserveTokenCache(AUTHTYPE_IAM, true)
+
+	expected := `# HELP ibm_sdk_request_duration_seconds The latency of the requests processed by services.
+# TYPE ibm_sdk_request_duration_seconds histogram
+ibm_sdk_request_duration_seconds_bucket{service="a\"b\\c",operation="",method="POST",status="error",le="0.1"} 0
+ibm_sdk_request_duration_seconds_bucket{service="a\"b\\c",operation="",method="POST",status="error",le="1"} 0
+ibm_sdk_request_duration_seconds_bucket{service="a\"b\\c",operation="",method="POST",status="error",le="+Inf"} 1
+ibm_sdk_request_duration_seconds_sum{service="a\"b\\c",operation="",method="POST",status="error"} 2
+ibm_sdk_request_duration_seconds_count{service="a\"b\\c",operation="",method="POST",status="error"} 1
+ibm_sdk_request_duration_seconds_bucket{service="my_service",operation="list_instances",method="GET",status="200",le="0.1"} 1
+ibm_sdk_request_duration_seconds_bucket{service="my_service",operation="list_instances",method="GET",status="200",le="1"} 2
+ibm_sdk_request_duration_seconds_bucket{service="my_service",operation="list_instances",method="GET",status="200",le="+Inf"} 2
+ibm_sdk_request_duration_seconds_sum{service="my_service",operation="list_instances",method="GET",status="200"} 0.55
+ibm_sdk_request_duration_seconds_count{service="my_service",operation="list_instances",method="GET",status="200"} 2
+# HELP ibm_sdk_request_retries_total The number of retries of the requests processed by services.
+# TYPE ibm_sdk_request_retries_total counter
+ibm_sdk_request_retries_total{service="a\"b\\c",operation="",method="POST"} 0
+ibm_sdk_request_retries_total{service="my_service",operation="list_instances",method="GET"} 2
+# HELP ibm_sdk_token_request_duration_seconds The latency of the token requests made by authenticators.
+# TYPE ibm_sdk_token_request_duration_seconds histogram
+ibm_sdk_token_request_duration_seconds_bucket{auth_type="iam",le="0.1"} 0
+ibm_sdk_token_request_duration_seconds_bucket{auth_type="iam",le="1"} 2
+ibm_sdk_token_request_duration_seconds_bucket{auth_type="iam",le="+Inf"} 2
+ibm_sdk_token_request_duration_seconds_sum{auth_type="iam"} 0.5
+ibm_sdk_token_request_duration_seconds_count{auth_type="iam"} 2
+# HELP ibm_sdk_token_request_failures_total The number of failed token requests made by authenticators.
+# TYPE ibm_sdk_token_request_failures_total counter
+ibm_sdk_token_request_failures_total{auth_type="iam"} 1
+# HELP ibm_sdk_token_cache_requests_total The number of tokens requested from authenticators, by cache result.
+# TYPE ibm_sdk_token_cache_requests_total counter
+ibm_sdk_token_cache_requests_total{auth_type="iam",result="hit"} 3
+ibm_sdk_token_cache_requests_total{auth_type="iam",result="miss"} 1
+`
+	var buf strings.Builder
+	assert.Nil(t, metrics.WritePrometheus(&buf))
+	assert.Equal(t, expected, buf.String())
+
+	assert.Equal(t, 0.75, metrics.TokenCacheHitRate(AUTHTYPE_IAM))
+	assert.Zero(t, metrics.TokenCacheHitRate(AUTHTYPE_VPC))
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest(GET, "/metrics", nil))
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", recorder.Header().Get(CONTENT_TYPE))
+	assert.Equal(t, expected, recorder.Body.String())
+
+	// Nothing is rendered for an empty InMemoryMetrics.
+	buf.Reset()
+	assert.Nil(t, NewInMemoryMetrics().WritePrometheus(&buf))
+	assert.Empty(t, buf.String())
+}
+
+func TestMetricsRequest(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	metrics := NewInMemoryMetrics()
+	SetMetrics(metrics)
+	defer SetMetrics(nil)
+	assert.Equal(t, metrics, GetMetrics())
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+	service.EnableRetries(1, 10*time.Millisecond)
+
+	builder := NewRequestBuilder(GET)
+	_, _ = builder.ResolveRequestURL(server.URL, "/instances", nil)
+	builder.AddHeader(headerNameSDKAnalytics, "service_name=my_service;service_version=V1;operation_id=list_instances")
+	req, _ := builder.Build()
+	_, err = service.Request(req, nil)
+	assert.Nil(t, err)
+
+	var buf strings.Builder
+	assert.Nil(t, metrics.WritePrometheus(&buf))
+	assert.Contains(t, buf.String(),
+		`ibm_sdk_request_duration_seconds_count{service="my_service",operation="list_instances",method="GET",status="200"} 1`)
+	assert.Contains(t, buf.String(),
+		`ibm_sdk_request_retries_total{service="my_service",operation="list_instances",method="GET"} 1`)
+}
+
+func TestMetricsTokens(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	fail := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"access_token": "token", "token_type": "Bearer", "expires_in": 3600, "expiration": %d}`,
+			GetCurrentTime()+3600)
+	}))
+	defer server.Close()
+
+	metrics := NewInMemoryMetrics()
+	SetMetrics(metrics)
+	defer SetMetrics(nil)
+
+	authenticator, err := NewIamAuthenticatorBuilder().SetApiKey("apikey").SetURL(server.URL).Build()
+	assert.Nil(t, err)
+	for i := 0; i < 4; i++ {
+		_, err = authenticator.GetToken()
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 0.75, metrics.TokenCacheHitRate(AUTHTYPE_IAM))
+
+	fail = true
+	_, err = authenticator.RequestToken()
+	assert.NotNil(t, err)
+
+	var buf strings.Builder
+	assert.Nil(t, metrics.WritePrometheus(&buf))
+	assert.Contains(t, buf.String(), `ibm_sdk_token_request_duration_seconds_count{auth_type="iam"} 2`)
+	assert.Contains(t, buf.String(), `ibm_sdk_token_request_failures_total{auth_type="iam"} 1`)
+}
@@ -0,0 +1,308 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+)
+
+const (
+	ERRORMSG_PAGER_NO_MORE_RESULTS = "no more results available"
+	ERRORMSG_PAGER_NIL_OPERATION   = "the pager operation cannot be nil"
+	ERRORMSG_PAGER_NIL_EXTRACTOR   = "the pager token extractor cannot be nil"
+	ERRORMSG_PAGER_BAD_OFFSET      = "invalid offset page token '%s'"
+)
+
+// PageRequest holds the information needed by a PagerOperation to retrieve a page of results.
+type PageRequest struct {
+	// Token is the page token to be used when retrieving the page
+	// (e.g. the value of the "start" or "offset" query parameter, or a cursor).
+	// This will be nil when retrieving the first page.
+	Token *string
+
+	// Limit is the maximum number of items to be returned in the page
+	// (e.g. the value of the "limit" query parameter).
+	// This will be nil if no page limit was configured on the pager,
+	// in which case the service's default page size should be used.
+	Limit *int64
+}
+
+// Page holds a single page of results returned by a PagerOperation.
+type Page[T any] struct {
+	// Items contains the items (resources) contained in the page.
+	Items []T
+
+	// Next contains the information needed to retrieve the next page: typically the
+	// "next.href" value of the list operation's response, or a cursor value.
+	// It should be nil if there is no next page.
+	Next *string
+
+	// TotalCount optionally contains the total number of items available
+	// (e.g. the "total_count" property of the list operation's response).
+	TotalCount *int64
+}
+
+// PageInfo describes the page that was just retrieved by a pager and is used
+// by a PageTokenExtractor to compute the token of the next page.
+type PageInfo struct {
+	// Token is the page token that was used to retrieve the page.
+	Token *string
+
+	// Limit is the page limit that was used to retrieve the page.
+	Limit *int64
+
+	// ItemCount is the number of items in the page.
+	ItemCount int
+
+	// Next and TotalCount are copied from the Page returned by the operation.
+	Next       *string
+	TotalCount *int64
+}
+
+// PagerOperation is a function that retrieves a single page of results from a paginated list operation.
+// This will typically be a closure that invokes a generated "List<resource>WithContext" method
+// after setting the page token and limit on the operation's options struct.
+type PagerOperation[T any] func(ctx context.Context, pageRequest *PageRequest) (*Page[T], error)
+
+// PageTokenExtractor is a function that computes the token to be used to retrieve the
+// next page of results, or returns nil if there are no more pages.
+type PageTokenExtractor func(pageInfo *PageInfo) (nextToken *string, err error)
+
+// NewHrefPageTokenExtractor returns a PageTokenExtractor that obtains the next page token
+// from the "param" query parameter within the "next" href of each page
+// (e.g. "start" for a next href of "https://myservice/v1/resources?limit=10&start=abc").
+func NewHrefPageTokenExtractor(param string) PageTokenExtractor {
+	return func(pageInfo *PageInfo) (*string, error) {
+		nextToken, err := GetQueryParam(pageInfo.Next, param)
+		return nextToken, RepurposeSDKProblem(err, "href-token-error")
+	}
+}
+
+// NewCursorPageTokenExtractor returns a PageTokenExtractor that uses the "next"
+// value of each page directly as the next page token.
+func NewCursorPageTokenExtractor() PageTokenExtractor {
+	return func(pageInfo *PageInfo) (*string, error) {
+		if pageInfo.Next == nil || *pageInfo.Next == "" {
+			return nil, nil
+		}
+		return pageInfo.Next, nil
+	}
+}
+
+// NewOffsetPageTokenExtractor returns a PageTokenExtractor that computes the next page token
+// as an item offset (the current offset plus the number of items in the current page).
+// Pagination stops when a page is empty, when a page contains fewer items than the page limit,
+// or when the offset reaches the page's total count.
+func NewOffsetPageTokenExtractor() PageTokenExtractor {
+	return func(pageInfo *PageInfo) (*string, error) {
+		var offset int64
+		if pageInfo.Token != nil {
+			var err error
+			offset, err = strconv.ParseInt(*pageInfo.Token, 10, 64)
+			if err != nil {
+				err = fmt.Errorf(ERRORMSG_PAGER_BAD_OFFSET, *pageInfo.Token)
+				return nil, SDKErrorf(err, "", "bad-offset-token", getComponentInfo())
+			}
+		}
+
+		if pageInfo.ItemCount == 0 {
+			return nil, nil
+		}
+		if pageInfo.Limit != nil && int64(pageInfo.ItemCount) < *pageInfo.Limit {
+			return nil, nil
+		}
+
+		offset += int64(pageInfo.ItemCount)
+		if pageInfo.TotalCount != nil && offset >= *pageInfo.TotalCount {
+			return nil, nil
+		}
+
+		nextToken := strconv.FormatInt(offset, 10)
+		return &nextToken, nil
+	}
+}
+
+// Pager retrieves the results of a paginated list operation one page at a time.
+type Pager[T any] struct {
+	operation PagerOperation[T]
+	extractor PageTokenExtractor
+
+	// Configuration.
+	pageLimit *int64
+	maxItems  int
+
+	// Pagination state.
+	hasNext    bool
+	nextToken  *string
+	itemsSoFar int
+
+	// Items of the most recent page that were not consumed by an Items() iteration.
+	pending []T
+}
+
+// NewPager returns a new Pager instance that will use "operation" to retrieve each page of results
+// and "extractor" to obtain the token for the next page.
+func NewPager[T any](operation PagerOperation[T], extractor PageTokenExtractor) (*Pager[T], error) {
+	if operation == nil {
+		return nil, SDKErrorf(errors.New(ERRORMSG_PAGER_NIL_OPERATION), "", "nil-operation", getComponentInfo())
+	}
+	if extractor == nil {
+		return nil, SDKErrorf(errors.New(ERRORMSG_PAGER_NIL_EXTRACTOR), "", "nil-extractor", getComponentInfo())
+	}
+
+	return &Pager[T]{
+		operation: operation,
+		extractor: extractor,
+		hasNext:   true,
+	}, nil
+}
+
+// WithPageLimit sets the page limit (page size) to be passed to the operation for each page.
+func (pager *Pager[T]) WithPageLimit(pageLimit int64) *Pager[T] {
+	pager.pageLimit = &pageLimit
+	return pager
+}
+
+// WithMaxItems sets the maximum number of items to be returned by the pager,
+// across all pages. A value of 0 means there is no maximum.
+func (pager *Pager[T]) WithMaxItems(maxItems int) *Pager[T] {
+	pager.maxItems = maxItems
+	return pager
+}
+
+// HasNext returns true if there are potentially more results to be retrieved.
+func (pager *Pager[T]) HasNext() bool {
+	return pager.hasNext || len(pager.pending) > 0
+}
+
+// GetNext invokes GetNextWithContext() using context.Background() as the Context parameter.
+func (pager *Pager[T]) GetNext() (page []T, err error) {
+	page, err = pager.GetNextWithContext(context.Background())
+	err = RepurposeSDKProblem(err, "")
+	return
+}
+
+// GetNextWithContext returns the next page of results.
+// If a previous Items() iteration was stopped part of the way through a page,
+// the remaining items of that page are returned as the next page.
+func (pager *Pager[T]) GetNextWithContext(ctx context.Context) (page []T, err error) {
+	if len(pager.pending) > 0 {
+		page, pager.pending = pager.pending, nil
+		return
+	}
+	if !pager.HasNext() {
+		err = SDKErrorf(errors.New(ERRORMSG_PAGER_NO_MORE_RESULTS), "", "no-more-results", getComponentInfo())
+		return
+	}
+	if ctx.Err() != nil {
+		err = SDKErrorf(ctx.Err(), "", "context-done", getComponentInfo())
+		return
+	}
+
+	pageRequest := &PageRequest{
+		Token: pager.nextToken,
+		Limit: pager.pageLimit,
+	}
+	result, err := pager.operation(ctx, pageRequest)
+	if err != nil {
+		err = SDKErrorf(err, "", "operation-error", getComponentInfo())
+		return
+	}
+	if result == nil {
+		result = &Page[T]{}
+	}
+
+	next, err := pager.extractor(&PageInfo{
+		Token:      pageRequest.Token,
+		Limit:      pageRequest.Limit,
+		ItemCount:  len(result.Items),
+		Next:       result.Next,
+		TotalCount: result.TotalCount,
+	})
+	if err != nil {
+		err = RepurposeSDKProblem(err, "extractor-error")
+		return
+	}
+
+	page = result.Items
+	pager.nextToken = next
+	pager.hasNext = next != nil
+	pager.itemsSoFar += len(page)
+
+	if pager.maxItems > 0 && pager.itemsSoFar >= pager.maxItems {
+		page = page[:len(page)-(pager.itemsSoFar-pager.maxItems)]
+		pager.itemsSoFar = pager.maxItems
+		pager.hasNext = false
+	}
+	return
+}
+
+// GetAll invokes GetAllWithContext() using context.Background() as the Context parameter.
+func (pager *Pager[T]) GetAll() (allItems []T, err error) {
+	allItems, err = pager.GetAllWithContext(context.Background())
+	err = RepurposeSDKProblem(err, "")
+	return
+}
+
+// GetAllWithContext returns all remaining results by invoking GetNextWithContext() repeatedly
+// until all pages of results have been retrieved.
+func (pager *Pager[T]) GetAllWithContext(ctx context.Context) (allItems []T, err error) {
+	for pager.HasNext() {
+		var nextPage []T
+		nextPage, err = pager.GetNextWithContext(ctx)
+		if err != nil {
+			err = RepurposeSDKProblem(err, "get-next-error")
+			return
+		}
+		allItems = append(allItems, nextPage...)
+	}
+	return
+}
+
+// Items returns an iterator over all remaining results, retrieving pages as needed.
+// If an error occurs while retrieving a page, the error is yielded along with the zero
+// value of T and the iteration stops. If the iteration is stopped by the caller,
+// a subsequent iteration (or call to GetNext) resumes with the next unconsumed item.
+//
+// Example:
+//
+//	for item, err := range pager.Items(ctx) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    ...
+//	}
+func (pager *Pager[T]) Items(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for pager.HasNext() {
+			page, err := pager.GetNextWithContext(ctx)
+			if err != nil {
+				var zero T
+				yield(zero, RepurposeSDKProblem(err, "get-next-error"))
+				return
+			}
+			for i, item := range page {
+				if !yield(item, nil) {
+					pager.pending = page[i+1:]
+					return
+				}
+			}
+		}
+	}
+}
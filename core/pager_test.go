@@ -0,0 +1,307 @@
+//go:build all || fast || basesvc
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pagerTestCollection simulates a generated list operation response model.
+type pagerTestCollection struct {
+	Resources  []string `json:"resources"`
+	TotalCount *int64   `json:"total_count"`
+	Next       *struct {
+		Href *string `json:"href"`
+	} `json:"next"`
+}
+
+// startPagerTestServer starts a server that serves "items" in pages of the requested size,
+// using the "start" query parameter to identify each page.
+func startPagerTestServer(items []string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil {
+			limit = 2
+		}
+		end := min(start+limit, len(items))
+
+		w.Header().Set("Content-Type", "application/json")
+		if end < len(items) {
+			fmt.Fprintf(w, `{"resources": %s, "next": {"href": "%s/resources?limit=%d&start=%d"}}`,
+				toJSON(items[start:end]), "http://"+r.Host, limit, end)
+		} else {
+			fmt.Fprintf(w, `{"resources": %s}`, toJSON(items[start:end]))
+		}
+	}))
+}
+
+// newPagerTestOperation returns a PagerOperation that invokes the list operation
+// of the server at "url", along with a counter of the operation invocations.
+func newPagerTestOperation(t *testing.T, url string) (PagerOperation[string], *int) {
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           url,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+
+	invocations := 0
+	return func(ctx context.Context, pageRequest *PageRequest) (*Page[string], error) {
+		invocations++
+		builder := NewRequestBuilder(GET).WithContext(ctx)
+		_, err := builder.ResolveRequestURL(url, "/resources", nil)
+		if err != nil {
+			return nil, err
+		}
+		if pageRequest.Token != nil {
+			builder.AddQuery("start", *pageRequest.Token)
+		}
+		if pageRequest.Limit != nil {
+			builder.AddQuery("limit", strconv.FormatInt(*pageRequest.Limit, 10))
+		}
+		req, err := builder.Build()
+		if err != nil {
+			return nil, err
+		}
+
+		var result *pagerTestCollection
+		_, err = service.Request(req, &result)
+		if err != nil {
+			return nil, err
+		}
+
+		page := &Page[string]{Items: result.Resources}
+		if result.Next != nil {
+			page.Next = result.Next.Href
+		}
+		return page, nil
+	}, &invocations
+}
+
+func TestPagerGetNext(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startPagerTestServer([]string{"a", "b", "c", "d", "e"})
+	defer server.Close()
+
+	operation, _ := newPagerTestOperation(t, server.URL)
+	pager, err := NewPager(operation, NewHrefPageTokenExtractor("start"))
+	assert.Nil(t, err)
+
+	assert.True(t, pager.HasNext())
+	page, err := pager.GetNext()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, page)
+
+	page, err = pager.GetNext()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"c", "d"}, page)
+
+	page, err = pager.GetNext()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"e"}, page)
+	assert.False(t, pager.HasNext())
+
+	page, err = pager.GetNext()
+	assert.Nil(t, page)
+	assert.NotNil(t, err)
+	assert.Equal(t, ERRORMSG_PAGER_NO_MORE_RESULTS, err.Error())
+}
+
+func TestPagerGetAllWithPageLimit(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startPagerTestServer([]string{"a", "b", "c", "d", "e"})
+	defer server.Close()
+
+	operation, invocations := newPagerTestOperation(t, server.URL)
+	pager, err := NewPager(operation, NewHrefPageTokenExtractor("start"))
+	assert.Nil(t, err)
+
+	allItems, err := pager.WithPageLimit(3).GetAll()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, allItems)
+	assert.Equal(t, 2, *invocations)
+	assert.False(t, pager.HasNext())
+}
+
+func TestPagerMaxItems(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startPagerTestServer([]string{"a", "b", "c", "d", "e"})
+	defer server.Close()
+
+	operation, invocations := newPagerTestOperation(t, server.URL)
+	pager, err := NewPager(operation, NewHrefPageTokenExtractor("start"))
+	assert.Nil(t, err)
+
+	allItems, err := pager.WithMaxItems(3).GetAll()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, allItems)
+	assert.Equal(t, 2, *invocations)
+}
+
+func TestPagerItems(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startPagerTestServer([]string{"a", "b", "c", "d", "e"})
+	defer server.Close()
+
+	operation, invocations := newPagerTestOperation(t, server.URL)
+	pager, err := NewPager(operation, NewHrefPageTokenExtractor("start"))
+	assert.Nil(t, err)
+
+	var items []string
+	for item, err := range pager.Items(context.Background()) {
+		assert.Nil(t, err)
+		items = append(items, item)
+		if item == "c" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, items)
+	assert.Equal(t, 2, *invocations)
+
+	// The pager should resume with the page after the last one retrieved.
+	for item, err := range pager.Items(context.Background()) {
+		assert.Nil(t, err)
+		items = append(items, item)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, items)
+}
+
+func TestPagerItemsContextCanceled(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startPagerTestServer([]string{"a", "b", "c", "d", "e"})
+	defer server.Close()
+
+	operation, invocations := newPagerTestOperation(t, server.URL)
+	pager, err := NewPager(operation, NewHrefPageTokenExtractor("start"))
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var items []string
+	var iterErr error
+	for item, err := range pager.Items(ctx) {
+		if err != nil {
+			iterErr = err
+			break
+		}
+		items = append(items, item)
+		cancel()
+	}
+	assert.Equal(t, []string{"a", "b"}, items)
+	assert.NotNil(t, iterErr)
+	assert.True(t, errors.Is(iterErr, context.Canceled))
+	assert.Equal(t, 1, *invocations)
+}
+
+func TestPagerOperationError(t *testing.T) {
+	pager, err := NewPager(func(ctx context.Context, pageRequest *PageRequest) (*Page[int], error) {
+		return nil, errors.New("list failed")
+	}, NewCursorPageTokenExtractor())
+	assert.Nil(t, err)
+
+	allItems, err := pager.GetAll()
+	assert.Nil(t, allItems)
+	assert.NotNil(t, err)
+	assert.Equal(t, "list failed", err.Error())
+	assert.True(t, pager.HasNext())
+}
+
+func TestPagerCursor(t *testing.T) {
+	cursors := map[string]*string{
+		"":   StringPtr("c1"),
+		"c1": StringPtr("c2"),
+		"c2": nil,
+	}
+	var tokens []string
+	pager, err := NewPager(func(ctx context.Context, pageRequest *PageRequest) (*Page[string], error) {
+		token := StringNilMapper(pageRequest.Token)
+		tokens = append(tokens, token)
+		return &Page[string]{Items: []string{"item-" + token}, Next: cursors[token]}, nil
+	}, NewCursorPageTokenExtractor())
+	assert.Nil(t, err)
+
+	allItems, err := pager.GetAll()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"item-", "item-c1", "item-c2"}, allItems)
+	assert.Equal(t, []string{"", "c1", "c2"}, tokens)
+}
+
+func TestPagerOffset(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4, 5, 6}
+	var offsets []string
+	pager, err := NewPager(func(ctx context.Context, pageRequest *PageRequest) (*Page[int], error) {
+		offsets = append(offsets, StringNilMapper(pageRequest.Token))
+		offset, _ := strconv.Atoi(StringNilMapper(pageRequest.Token))
+		end := min(offset+int(*pageRequest.Limit), len(items))
+		return &Page[int]{Items: items[offset:end], TotalCount: Int64Ptr(int64(len(items)))}, nil
+	}, NewOffsetPageTokenExtractor())
+	assert.Nil(t, err)
+
+	allItems, err := pager.WithPageLimit(3).GetAll()
+	assert.Nil(t, err)
+	assert.Equal(t, items, allItems)
+	assert.Equal(t, []string{"", "3", "6"}, offsets)
+}
+
+func TestOffsetPageTokenExtractor(t *testing.T) {
+	extractor := NewOffsetPageTokenExtractor()
+
+	next, err := extractor(&PageInfo{ItemCount: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, "10", *next)
+
+	next, err = extractor(&PageInfo{Token: StringPtr("10"), ItemCount: 10, TotalCount: Int64Ptr(30)})
+	assert.Nil(t, err)
+	assert.Equal(t, "20", *next)
+
+	next, err = extractor(&PageInfo{Token: StringPtr("20"), ItemCount: 10, TotalCount: Int64Ptr(30)})
+	assert.Nil(t, err)
+	assert.Nil(t, next)
+
+	next, err = extractor(&PageInfo{Token: StringPtr("20"), ItemCount: 5, Limit: Int64Ptr(10)})
+	assert.Nil(t, err)
+	assert.Nil(t, next)
+
+	next, err = extractor(&PageInfo{Token: StringPtr("20"), ItemCount: 0})
+	assert.Nil(t, err)
+	assert.Nil(t, next)
+
+	next, err = extractor(&PageInfo{Token: StringPtr("abc"), ItemCount: 10})
+	assert.Nil(t, next)
+	assert.NotNil(t, err)
+	assert.Equal(t, "invalid offset page token 'abc'", err.Error())
+}
+
+func TestNewPagerErrors(t *testing.T) {
+	pager, err := NewPager[string](nil, NewCursorPageTokenExtractor())
+	assert.Nil(t, pager)
+	assert.Equal(t, ERRORMSG_PAGER_NIL_OPERATION, err.Error())
+
+	pager, err = NewPager(func(ctx context.Context, pageRequest *PageRequest) (*Page[string], error) {
+		return nil, nil
+	}, nil)
+	assert.Nil(t, pager)
+	assert.Equal(t, ERRORMSG_PAGER_NIL_EXTRACTOR, err.Error())
+}
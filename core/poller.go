@@ -0,0 +1,256 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultPollMinInterval is the default minimum wait time between two polls.
+	DefaultPollMinInterval = 1 * time.Second
+
+	// DefaultPollMaxInterval is the default maximum wait time between two polls.
+	DefaultPollMaxInterval = 30 * time.Second
+
+	ERRORMSG_POLLER_NIL_OPERATION  = "the poll operation cannot be nil"
+	ERRORMSG_POLLER_NIL_STATE_FUNC = "the poll state function cannot be nil"
+	ERRORMSG_POLLER_FAILED         = "the operation reached a failed state after %d poll(s)"
+	ERRORMSG_POLLER_TIMEOUT        = "the operation did not reach a terminal state within %s (%d poll(s))"
+	ERRORMSG_POLLER_CONTEXT_DONE   = "polling was interrupted after %d poll(s): %s"
+)
+
+// PollState describes the state of a long-running operation.
+type PollState int
+
+// PollState constants
+const (
+	// PollStateInProgress indicates the operation has not yet reached a terminal state.
+	PollStateInProgress PollState = iota
+
+	// PollStateSucceeded indicates the operation completed successfully.
+	PollStateSucceeded
+
+	// PollStateFailed indicates the operation completed unsuccessfully.
+	PollStateFailed
+)
+
+// PollOperation is a function that retrieves the current status of a long-running operation.
+// This will typically be a closure that invokes a generated "Get<resource>WithContext" method,
+// or the function returned by NewRequestPollOperation().
+type PollOperation func(ctx context.Context) (*DetailedResponse, error)
+
+// PollStateFunc is a function that determines the state of a long-running operation
+// from the response returned by a PollOperation.
+type PollStateFunc func(detailedResponse *DetailedResponse) (PollState, error)
+
+// NewRequestPollOperation returns a PollOperation that uses "service" to invoke
+// the request built by "builder" each time it is called. The response body is
+// unmarshalled into a generic JSON object (map[string]interface{}) so that its
+// properties are available to a PollStateFunc via DetailedResponse.GetResultAsMap().
+func NewRequestPollOperation(service *BaseService, builder *RequestBuilder) PollOperation {
+	return func(ctx context.Context) (*DetailedResponse, error) {
+		req, err := builder.WithContext(ctx).Build()
+		if err != nil {
+			return nil, RepurposeSDKProblem(err, "build-poll-request-error")
+		}
+
+		var result map[string]interface{}
+		return service.Request(req, &result)
+	}
+}
+
+// NewPropertyPollStateFunc returns a PollStateFunc that determines the state of an operation
+// from a string property (e.g. "status") of the generic JSON object contained in the
+// DetailedResponse's Result field. The operation is considered to have succeeded
+// if the property's value is one of "succeeded" and failed if it is one of "failed".
+// Any other value (or a missing property) indicates the operation is still in progress.
+func NewPropertyPollStateFunc(property string, succeeded []string, failed []string) PollStateFunc {
+	return func(detailedResponse *DetailedResponse) (PollState, error) {
+		if detailedResponse == nil {
+			return PollStateInProgress, nil
+		}
+		if resultMap, ok := detailedResponse.GetResultAsMap(); ok {
+			if value, ok := resultMap[property].(string); ok {
+				if SliceContains(succeeded, value) {
+					return PollStateSucceeded, nil
+				}
+				if SliceContains(failed, value) {
+					return PollStateFailed, nil
+				}
+			}
+		}
+		return PollStateInProgress, nil
+	}
+}
+
+// Poller repeatedly invokes a PollOperation until the long-running operation
+// reaches a terminal state, waiting between polls using an exponential backoff
+// that honors the "Retry-After" response header.
+type Poller struct {
+	operation PollOperation
+	stateFunc PollStateFunc
+
+	minInterval time.Duration
+	maxInterval time.Duration
+	timeout     time.Duration
+}
+
+// NewPoller returns a new Poller instance that will use "operation" to retrieve the
+// status of a long-running operation, and "stateFunc" to determine its state.
+func NewPoller(operation PollOperation, stateFunc PollStateFunc) (*Poller, error) {
+	if operation == nil {
+		return nil, SDKErrorf(errors.New(ERRORMSG_POLLER_NIL_OPERATION), "", "nil-operation", getComponentInfo())
+	}
+	if stateFunc == nil {
+		return nil, SDKErrorf(errors.New(ERRORMSG_POLLER_NIL_STATE_FUNC), "", "nil-state-func", getComponentInfo())
+	}
+
+	return &Poller{
+		operation:   operation,
+		stateFunc:   stateFunc,
+		minInterval: DefaultPollMinInterval,
+		maxInterval: DefaultPollMaxInterval,
+	}, nil
+}
+
+// WithInterval sets the minimum and maximum wait time between two polls.
+// If either value is specified as 0, then the default value is used instead.
+func (poller *Poller) WithInterval(minInterval time.Duration, maxInterval time.Duration) *Poller {
+	poller.minInterval = DefaultPollMinInterval
+	if minInterval > 0 {
+		poller.minInterval = minInterval
+	}
+	poller.maxInterval = DefaultPollMaxInterval
+	if maxInterval > 0 {
+		poller.maxInterval = maxInterval
+	}
+	return poller
+}
+
+// WithTimeout sets the overall amount of time allowed for the operation to reach
+// a terminal state. A value of 0 means there is no timeout other than that of the
+// Context passed to PollWithContext().
+func (poller *Poller) WithTimeout(timeout time.Duration) *Poller {
+	poller.timeout = timeout
+	return poller
+}
+
+// Poll invokes PollWithContext() using context.Background() as the Context parameter.
+func (poller *Poller) Poll() (detailedResponse *DetailedResponse, err error) {
+	detailedResponse, err = poller.PollWithContext(context.Background())
+	err = RepurposeSDKProblem(err, "")
+	return
+}
+
+// PollWithContext polls the long-running operation until it reaches a terminal state,
+// and returns the final DetailedResponse.
+// If the operation reaches a failed state, or does not reach a terminal state before
+// the poller's timeout expires or "ctx" is done, then an SDKProblem is returned along with
+// the most recent DetailedResponse received.
+// Error responses with a retryable status code (see IBMCloudSDKRetryPolicy) are treated
+// as an indication the operation is still in progress; all other errors stop the polling.
+func (poller *Poller) PollWithContext(ctx context.Context) (detailedResponse *DetailedResponse, err error) {
+	pollCtx := ctx
+	if poller.timeout > 0 {
+		var cancel context.CancelFunc
+		pollCtx, cancel = context.WithTimeout(ctx, poller.timeout)
+		defer cancel()
+	}
+
+	for attempt := 0; ; attempt++ {
+		var state PollState
+		var response *DetailedResponse
+		response, err = poller.operation(pollCtx)
+		if response != nil {
+			detailedResponse = response
+		}
+		if err != nil {
+			if pollCtx.Err() != nil {
+				err = poller.contextError(ctx, pollCtx, attempt+1)
+				return
+			}
+			if !isRetryableResponse(pollCtx, response) {
+				err = SDKErrorf(err, "", "poll-operation-error", getComponentInfo())
+				return
+			}
+			GetLogger().Debug("Poll attempt %d failed with a retryable status code, continuing...", attempt+1)
+		} else {
+			state, err = poller.stateFunc(response)
+			if err != nil {
+				err = SDKErrorf(err, "", "poll-state-error", getComponentInfo())
+				return
+			}
+		}
+
+		switch state {
+		case PollStateSucceeded:
+			GetLogger().Debug("Operation succeeded after %d poll(s)", attempt+1)
+			return
+		case PollStateFailed:
+			err = SDKErrorf(nil, fmt.Sprintf(ERRORMSG_POLLER_FAILED, attempt+1), "operation-failed", getComponentInfo())
+			return
+		}
+
+		wait := IBMCloudSDKBackoffPolicy(poller.minInterval, poller.maxInterval, attempt, toHTTPResponse(response))
+		GetLogger().Debug("Operation in progress after %d poll(s), next poll in %s", attempt+1, wait.String())
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-pollCtx.Done():
+			timer.Stop()
+			err = poller.contextError(ctx, pollCtx, attempt+1)
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// contextError returns the SDKProblem that describes why polling was interrupted:
+// either the poller's own timeout expired, or the caller's context is done.
+func (poller *Poller) contextError(ctx context.Context, pollCtx context.Context, polls int) error {
+	if ctx.Err() == nil && errors.Is(pollCtx.Err(), context.DeadlineExceeded) {
+		err := fmt.Errorf(ERRORMSG_POLLER_TIMEOUT, poller.timeout.String(), polls)
+		return SDKErrorf(err, "", "operation-timeout", getComponentInfo())
+	}
+	err := fmt.Errorf(ERRORMSG_POLLER_CONTEXT_DONE, polls, pollCtx.Err().Error())
+	return SDKErrorf(err, "", "context-done", getComponentInfo())
+}
+
+// isRetryableResponse returns true if "detailedResponse" describes an error
+// response that would be retried by IBMCloudSDKRetryPolicy.
+func isRetryableResponse(ctx context.Context, detailedResponse *DetailedResponse) bool {
+	if detailedResponse == nil {
+		return false
+	}
+	retry, _ := IBMCloudSDKRetryPolicy(ctx, toHTTPResponse(detailedResponse), nil)
+	return retry
+}
+
+// toHTTPResponse returns an http.Response containing the status code and headers
+// of "detailedResponse" so that it can be passed to the retry and backoff policies.
+func toHTTPResponse(detailedResponse *DetailedResponse) *http.Response {
+	if detailedResponse == nil {
+		return nil
+	}
+	return &http.Response{
+		StatusCode: detailedResponse.StatusCode,
+		Header:     detailedResponse.Headers,
+	}
+}
@@ -0,0 +1,229 @@
+//go:build all || fast || basesvc
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// startPollerTestServer starts a server that returns each of "statuses" in turn
+// (repeating the last one), as the "status" property of a JSON response.
+// A numeric status is returned as an error response with that status code.
+func startPollerTestServer(statuses ...interface{}) (*httptest.Server, *int) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[min(polls, len(statuses)-1)]
+		polls++
+		w.Header().Set("Content-Type", "application/json")
+		if statusCode, ok := status.(int); ok {
+			if statusCode == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "1")
+			}
+			w.WriteHeader(statusCode)
+			fmt.Fprint(w, `{"error": "try again"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"id": "op-1", "status": "%s"}`, status)
+	}))
+	return server, &polls
+}
+
+func newTestPoller(t *testing.T, url string) *Poller {
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           url,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+
+	builder := NewRequestBuilder(GET)
+	_, err = builder.ResolveRequestURL(url, "/operations/{id}", map[string]string{"id": "op-1"})
+	assert.Nil(t, err)
+
+	poller, err := NewPoller(NewRequestPollOperation(service, builder),
+		NewPropertyPollStateFunc("status", []string{"completed"}, []string{"failed"}))
+	assert.Nil(t, err)
+	return poller.WithInterval(time.Millisecond, 5*time.Millisecond)
+}
+
+func TestPollerSucceeded(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server, polls := startPollerTestServer("pending", "running", "running", "completed")
+	defer server.Close()
+
+	detailedResponse, err := newTestPoller(t, server.URL).Poll()
+	assert.Nil(t, err)
+	assert.NotNil(t, detailedResponse)
+	assert.Equal(t, 4, *polls)
+
+	result, ok := detailedResponse.GetResultAsMap()
+	assert.True(t, ok)
+	assert.Equal(t, "completed", result["status"])
+}
+
+func TestPollerFailed(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server, polls := startPollerTestServer("pending", "failed")
+	defer server.Close()
+
+	detailedResponse, err := newTestPoller(t, server.URL).Poll()
+	assert.NotNil(t, err)
+	assert.Equal(t, "the operation reached a failed state after 2 poll(s)", err.Error())
+	assert.Equal(t, 2, *polls)
+
+	result, _ := detailedResponse.GetResultAsMap()
+	assert.Equal(t, "failed", result["status"])
+
+	sdkProblem, ok := err.(*SDKProblem)
+	assert.True(t, ok)
+	assert.Equal(t, "operation-failed", sdkProblem.discriminator)
+}
+
+func TestPollerTimeout(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server, _ := startPollerTestServer("running")
+	defer server.Close()
+
+	detailedResponse, err := newTestPoller(t, server.URL).WithTimeout(50 * time.Millisecond).Poll()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "the operation did not reach a terminal state within 50ms")
+	assert.NotNil(t, detailedResponse)
+
+	sdkProblem, ok := err.(*SDKProblem)
+	assert.True(t, ok)
+	assert.Equal(t, "operation-timeout", sdkProblem.discriminator)
+}
+
+func TestPollerContextCanceled(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server, _ := startPollerTestServer("running")
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := newTestPoller(t, server.URL).WithTimeout(time.Minute).PollWithContext(ctx)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "polling was interrupted")
+	assert.Contains(t, err.Error(), context.DeadlineExceeded.Error())
+
+	sdkProblem, ok := err.(*SDKProblem)
+	assert.True(t, ok)
+	assert.Equal(t, "context-done", sdkProblem.discriminator)
+}
+
+func TestPollerRetryableErrorWithRetryAfter(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server, polls := startPollerTestServer(http.StatusTooManyRequests, http.StatusServiceUnavailable, "completed")
+	defer server.Close()
+
+	start := time.Now()
+	detailedResponse, err := newTestPoller(t, server.URL).Poll()
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, detailedResponse.StatusCode)
+	assert.Equal(t, 3, *polls)
+
+	// The Retry-After header of the 429 response should have been honored.
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func TestPollerNonRetryableError(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server, polls := startPollerTestServer("running", http.StatusNotFound)
+	defer server.Close()
+
+	detailedResponse, err := newTestPoller(t, server.URL).Poll()
+	assert.NotNil(t, err)
+	assert.Equal(t, "try again", err.Error())
+	assert.Equal(t, http.StatusNotFound, detailedResponse.StatusCode)
+	assert.Equal(t, 2, *polls)
+}
+
+func TestPollerStateFuncError(t *testing.T) {
+	poller, err := NewPoller(func(ctx context.Context) (*DetailedResponse, error) {
+		return &DetailedResponse{StatusCode: http.StatusOK}, nil
+	}, func(detailedResponse *DetailedResponse) (PollState, error) {
+		return PollStateInProgress, errors.New("unrecognized status")
+	})
+	assert.Nil(t, err)
+
+	_, err = poller.Poll()
+	assert.NotNil(t, err)
+	assert.Equal(t, "unrecognized status", err.Error())
+}
+
+func TestPollerBackoff(t *testing.T) {
+	var pollTimes []time.Time
+	poller, err := NewPoller(func(ctx context.Context) (*DetailedResponse, error) {
+		pollTimes = append(pollTimes, time.Now())
+		return &DetailedResponse{StatusCode: http.StatusOK}, nil
+	}, func(detailedResponse *DetailedResponse) (PollState, error) {
+		if len(pollTimes) == 4 {
+			return PollStateSucceeded, nil
+		}
+		return PollStateInProgress, nil
+	})
+	assert.Nil(t, err)
+
+	_, err = poller.WithInterval(10*time.Millisecond, 25*time.Millisecond).Poll()
+	assert.Nil(t, err)
+	assert.Len(t, pollTimes, 4)
+
+	// Expected waits: 10ms, 20ms, then capped at 25ms.
+	assert.GreaterOrEqual(t, pollTimes[1].Sub(pollTimes[0]), 10*time.Millisecond)
+	assert.GreaterOrEqual(t, pollTimes[2].Sub(pollTimes[1]), 20*time.Millisecond)
+	assert.GreaterOrEqual(t, pollTimes[3].Sub(pollTimes[2]), 25*time.Millisecond)
+}
+
+func TestNewPollerErrors(t *testing.T) {
+	poller, err := NewPoller(nil, NewPropertyPollStateFunc("status", nil, nil))
+	assert.Nil(t, poller)
+	assert.Equal(t, ERRORMSG_POLLER_NIL_OPERATION, err.Error())
+
+	poller, err = NewPoller(func(ctx context.Context) (*DetailedResponse, error) {
+		return nil, nil
+	}, nil)
+	assert.Nil(t, poller)
+	assert.Equal(t, ERRORMSG_POLLER_NIL_STATE_FUNC, err.Error())
+}
+
+func TestPropertyPollStateFunc(t *testing.T) {
+	stateFunc := NewPropertyPollStateFunc("status", []string{"completed"}, []string{"failed", "canceled"})
+
+	state, err := stateFunc(&DetailedResponse{Result: map[string]interface{}{"status": "completed"}})
+	assert.Nil(t, err)
+	assert.Equal(t, PollStateSucceeded, state)
+
+	state, _ = stateFunc(&DetailedResponse{Result: map[string]interface{}{"status": "canceled"}})
+	assert.Equal(t, PollStateFailed, state)
+
+	state, _ = stateFunc(&DetailedResponse{Result: map[string]interface{}{"status": "running"}})
+	assert.Equal(t, PollStateInProgress, state)
+
+	state, _ = stateFunc(&DetailedResponse{Result: map[string]interface{}{"state": "completed"}})
+	assert.Equal(t, PollStateInProgress, state)
+
+	state, _ = stateFunc(&DetailedResponse{})
+	assert.Equal(t, PollStateInProgress, state)
+}
@@ -0,0 +1,261 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	headerNameRateLimitLimit     = "X-RateLimit-Limit"
+	headerNameRateLimitRemaining = "X-RateLimit-Remaining"
+	headerNameRateLimitReset     = "X-RateLimit-Reset"
+	headerNameRetryAfter         = "Retry-After"
+
+	// X-RateLimit-Reset values greater than this are interpreted as a Unix time
+	// rather than a number of seconds.
+	rateLimitResetEpochThreshold = 1000000000
+
+	ERRORMSG_RATE_LIMIT_EXCEEDED = "The request was not sent because the client-side rate limit was exceeded; retry in %s"
+)
+
+// RateLimiter limits the rate at which one or more BaseService instances send requests
+// (see ServiceOptions.RateLimiter), so that they stay within a service's quota instead
+// of reacting to 429 (Too Many Requests) responses after the fact.
+//
+// The limiter combines:
+//   - an optional static limit of "requestsPerSecond", with bursts of up to "burst" requests
+//   - the quota advertised by the "X-RateLimit-Limit", "X-RateLimit-Remaining" and
+//     "X-RateLimit-Reset" headers of the responses received: once the remaining quota is used up,
+//     requests are held back until the quota is reset
+//   - the "Retry-After" header of 429 and 503 responses: requests are held back until then
+//
+// By default, a request that can't be sent yet waits until it can (or until its Context
+// is done). If fail-fast mode is enabled, the request fails immediately with an error
+// whose discriminator is "rate-limit-exceeded" instead.
+// Automatic retries are also subject to the limiter, and are abandoned if they can't
+// be sent in fail-fast mode.
+//
+// A RateLimiter is safe for concurrent use and may be shared by multiple services.
+type RateLimiter struct {
+	mutex    sync.Mutex
+	rate     float64
+	burst    float64
+	failFast bool
+
+	// The static token bucket.
+	tokens float64
+	last   time.Time
+
+	// The quota advertised by the server; "remaining" is -1 if unknown.
+	// The quota is assumed to be reset every "window", starting at "resetAt".
+	limit     int
+	remaining int
+	resetAt   time.Time
+	window    time.Duration
+
+	// The time before which no request should be sent, from a "Retry-After" header.
+	blockedUntil time.Time
+
+	// now returns the current time; it can be replaced by tests.
+	now func() time.Time
+}
+
+// NewRateLimiter returns a new RateLimiter that allows "requestsPerSecond" requests
+// per second on average, with bursts of up to "burst" requests. If "burst" is not positive,
+// a burst of one second's worth of requests is allowed.
+// If "requestsPerSecond" is not positive, then there is no static limit and only the
+// rate limit headers of responses are honoured.
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if requestsPerSecond < 0 {
+		requestsPerSecond = 0
+	}
+	if burst <= 0 {
+		burst = int(math.Max(1, math.Ceil(requestsPerSecond)))
+	}
+	limiter := &RateLimiter{
+		rate:      requestsPerSecond,
+		burst:     float64(burst),
+		tokens:    float64(burst),
+		remaining: -1,
+		now:       time.Now,
+	}
+	limiter.last = limiter.now()
+	return limiter
+}
+
+// WithFailFast sets whether requests that can't be sent yet should fail immediately
+// (true) rather than wait until they can be sent (false, the default).
+func (limiter *RateLimiter) WithFailFast(failFast bool) *RateLimiter {
+	limiter.failFast = failFast
+	return limiter
+}
+
+// GetRequestsPerSecond returns the static limit of the rate limiter (0 if none).
+func (limiter *RateLimiter) GetRequestsPerSecond() float64 {
+	return limiter.rate
+}
+
+// GetBurst returns the maximum number of requests that may be sent in a burst.
+func (limiter *RateLimiter) GetBurst() int {
+	return int(limiter.burst)
+}
+
+// IsFailFast returns true if requests that can't be sent yet fail immediately.
+func (limiter *RateLimiter) IsFailFast() bool {
+	return limiter.failFast
+}
+
+// wait blocks until a request (or a retry, if "retry" is true) may be sent, and returns
+// an error if it must not be sent because fail-fast mode is enabled or "ctx" is done.
+func (limiter *RateLimiter) wait(ctx context.Context, retry bool) error {
+	delay, ok := limiter.reserve(retry)
+	if !ok {
+		err := fmt.Errorf(ERRORMSG_RATE_LIMIT_EXCEEDED, delay.String())
+		return SDKErrorf(err, "", "rate-limit-exceeded", getComponentInfo())
+	}
+	if delay <= 0 {
+		return nil
+	}
+
+	GetLogger().Debug("Rate limit reached, waiting %s before sending request\n", delay.String())
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return SDKErrorf(ctx.Err(), "", "rate-limit-wait-canceled", getComponentInfo())
+	}
+}
+
+// reserve reserves the right to send a request and returns how long the caller must wait
+// before sending it. In fail-fast mode, nothing is reserved if the caller would have to wait,
+// and false is returned along with the wait time.
+// A "Retry-After" header is ignored for a retry, since the backoff policy already honours it.
+func (limiter *RateLimiter) reserve(retry bool) (time.Duration, bool) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	now := limiter.now()
+	var delay time.Duration
+
+	// Honour a previous "Retry-After" header.
+	if !retry && now.Before(limiter.blockedUntil) {
+		delay = limiter.blockedUntil.Sub(now)
+	}
+
+	// Honour the quota advertised by the server, restoring it once it has been reset.
+	useQuota := false
+	if limiter.remaining >= 0 && !now.Before(limiter.resetAt) {
+		limiter.remaining = -1
+		if limiter.limit > 0 && limiter.window > 0 {
+			limiter.remaining = limiter.limit
+			for !now.Before(limiter.resetAt) {
+				limiter.resetAt = limiter.resetAt.Add(limiter.window)
+			}
+		}
+	}
+	if limiter.remaining == 0 {
+		delay = max(delay, limiter.resetAt.Sub(now))
+	} else if limiter.remaining > 0 {
+		useQuota = true
+	}
+
+	// Take a token from the static bucket.
+	tokens := limiter.tokens
+	if limiter.rate > 0 {
+		tokens = math.Min(limiter.burst, tokens+now.Sub(limiter.last).Seconds()*limiter.rate) - 1
+		if tokens < 0 {
+			delay = max(delay, time.Duration(-tokens/limiter.rate*float64(time.Second)))
+		}
+	}
+
+	if delay > 0 && limiter.failFast {
+		return delay, false
+	}
+
+	if limiter.rate > 0 {
+		limiter.tokens = tokens
+		limiter.last = now
+	}
+	if useQuota {
+		limiter.remaining--
+	}
+	return delay, true
+}
+
+// update adjusts the limiter according to the rate limit headers of "resp".
+func (limiter *RateLimiter) update(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	now := limiter.now()
+
+	if s := resp.Header.Get(headerNameRateLimitLimit); s != "" {
+		if n, err := strconv.Atoi(firstRateLimitValue(s)); err == nil && n > 0 {
+			limiter.limit = n
+		}
+	}
+
+	remaining, remainingErr := strconv.Atoi(firstRateLimitValue(resp.Header.Get(headerNameRateLimitRemaining)))
+	reset, resetErr := strconv.ParseInt(firstRateLimitValue(resp.Header.Get(headerNameRateLimitReset)), 10, 64)
+	if remainingErr == nil && resetErr == nil && remaining >= 0 {
+		limiter.remaining = remaining
+		if reset > rateLimitResetEpochThreshold {
+			limiter.resetAt = time.Unix(reset, 0)
+		} else {
+			limiter.resetAt = now.Add(time.Duration(reset) * time.Second)
+		}
+		// The time until the reset is at most the length of the window.
+		if window := limiter.resetAt.Sub(now); window > limiter.window {
+			limiter.window = window
+		}
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		if s := resp.Header.Get(headerNameRetryAfter); s != "" {
+			var until time.Time
+			if seconds, err := strconv.ParseInt(s, 10, 64); err == nil {
+				until = now.Add(time.Duration(seconds) * time.Second)
+			} else if t, err := http.ParseTime(s); err == nil {
+				until = t
+			}
+			if until.After(limiter.blockedUntil) {
+				limiter.blockedUntil = until
+			}
+		}
+	}
+}
+
+// firstRateLimitValue returns the first value of a rate limit header that contains a list
+// of quota policies (e.g. "100, 100;w=60").
+func firstRateLimitValue(s string) string {
+	if i := strings.IndexAny(s, ",;"); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,240 @@
+//go:build all || fast || basesvc || retries
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestRateLimiter returns a RateLimiter whose clock is controlled by the returned function.
+func newTestRateLimiter(requestsPerSecond float64, burst int) (*RateLimiter, func(time.Duration)) {
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRateLimiter(requestsPerSecond, burst)
+	limiter.now = func() time.Time {
+		return now
+	}
+	limiter.last = now
+	return limiter, func(d time.Duration) {
+		now = now.Add(d)
+	}
+}
+
+func rateLimitTestResponse(statusCode int, headers map[string]string) *http.Response {
+	resp := &http.Response{StatusCode: statusCode, Header: http.Header{}}
+	for name, value := range headers {
+		resp.Header.Set(name, value)
+	}
+	return resp
+}
+
+func TestRateLimiterStatic(t *testing.T) {
+	limiter, advance := newTestRateLimiter(10, 2)
+	assert.Equal(t, 10.0, limiter.GetRequestsPerSecond())
+	assert.Equal(t, 2, limiter.GetBurst())
+	assert.False(t, limiter.IsFailFast())
+
+	// The burst is available immediately, then requests are spaced 100ms apart.
+	delay, ok := limiter.reserve(false)
+	assert.True(t, ok)
+	assert.Zero(t, delay)
+	delay, _ = limiter.reserve(false)
+	assert.Zero(t, delay)
+	delay, _ = limiter.reserve(false)
+	assert.Equal(t, 100*time.Millisecond, delay)
+	delay, _ = limiter.reserve(false)
+	assert.Equal(t, 200*time.Millisecond, delay)
+
+	advance(time.Second)
+	delay, _ = limiter.reserve(false)
+	assert.Zero(t, delay)
+
+	// In fail-fast mode, nothing is reserved when the caller would have to wait.
+	limiter.WithFailFast(true)
+	assert.True(t, limiter.IsFailFast())
+	delay, ok = limiter.reserve(false)
+	assert.True(t, ok)
+	assert.Zero(t, delay)
+	delay, ok = limiter.reserve(false)
+	assert.False(t, ok)
+	assert.Equal(t, 100*time.Millisecond, delay)
+	advance(100 * time.Millisecond)
+	delay, ok = limiter.reserve(false)
+	assert.True(t, ok)
+	assert.Zero(t, delay)
+
+	limiter = NewRateLimiter(2.5, 0)
+	assert.Equal(t, 3, limiter.GetBurst())
+	limiter = NewRateLimiter(-1, 0)
+	assert.Zero(t, limiter.GetRequestsPerSecond())
+	assert.Equal(t, 1, limiter.GetBurst())
+}
+
+func TestRateLimiterHeaders(t *testing.T) {
+	limiter, advance := newTestRateLimiter(0, 0)
+
+	// The server's quota is used up, then restored from X-RateLimit-Limit once reset.
+	limiter.update(rateLimitTestResponse(http.StatusOK, map[string]string{
+		headerNameRateLimitLimit:     "3",
+		headerNameRateLimitRemaining: "1",
+		headerNameRateLimitReset:     "30",
+	}))
+	delay, ok := limiter.reserve(false)
+	assert.True(t, ok)
+	assert.Zero(t, delay)
+	delay, _ = limiter.reserve(false)
+	assert.Equal(t, 30*time.Second, delay)
+
+	advance(30 * time.Second)
+	for i := 0; i < 3; i++ {
+		delay, _ = limiter.reserve(false)
+		assert.Zero(t, delay)
+	}
+	delay, _ = limiter.reserve(false)
+	assert.Equal(t, 30*time.Second, delay)
+
+	// X-RateLimit-Reset may also be a Unix time, and the headers may list several policies.
+	resetAt := limiter.now().Add(10 * time.Second)
+	limiter.update(rateLimitTestResponse(http.StatusOK, map[string]string{
+		headerNameRateLimitLimit:     "100, 100;w=60",
+		headerNameRateLimitRemaining: "0",
+		headerNameRateLimitReset:     strconv.FormatInt(resetAt.Unix(), 10),
+	}))
+	delay, _ = limiter.reserve(false)
+	assert.Equal(t, 10*time.Second, delay)
+	assert.Equal(t, 100, limiter.limit)
+
+	// Unparseable headers are ignored.
+	limiter, _ = newTestRateLimiter(0, 0)
+	limiter.update(rateLimitTestResponse(http.StatusOK, map[string]string{
+		headerNameRateLimitRemaining: "none",
+		headerNameRateLimitReset:     "30",
+	}))
+	delay, _ = limiter.reserve(false)
+	assert.Zero(t, delay)
+	limiter.update(nil)
+}
+
+func TestRateLimiterRetryAfter(t *testing.T) {
+	limiter, advance := newTestRateLimiter(0, 0)
+
+	// Retry-After is only honoured on 429 and 503 responses.
+	limiter.update(rateLimitTestResponse(http.StatusOK, map[string]string{headerNameRetryAfter: "5"}))
+	delay, _ := limiter.reserve(false)
+	assert.Zero(t, delay)
+
+	limiter.update(rateLimitTestResponse(http.StatusTooManyRequests, map[string]string{headerNameRetryAfter: "5"}))
+	delay, _ = limiter.reserve(false)
+	assert.Equal(t, 5*time.Second, delay)
+
+	// Retries are already delayed by the backoff policy.
+	delay, _ = limiter.reserve(true)
+	assert.Zero(t, delay)
+
+	retryAt := limiter.now().Add(time.Minute).Format(http.TimeFormat)
+	limiter.update(rateLimitTestResponse(http.StatusServiceUnavailable, map[string]string{headerNameRetryAfter: retryAt}))
+	delay, _ = limiter.reserve(false)
+	assert.Equal(t, time.Minute, delay)
+
+	advance(time.Minute)
+	delay, _ = limiter.reserve(false)
+	assert.Zero(t, delay)
+}
+
+func TestRateLimiterFailFast(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set(headerNameRateLimitLimit, "2")
+		w.Header().Set(headerNameRateLimitRemaining, strconv.Itoa(2-requests))
+		w.Header().Set(headerNameRateLimitReset, "60")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+	service.SetRateLimiter(NewRateLimiter(0, 0).WithFailFast(true))
+	assert.NotNil(t, service.GetRateLimiter())
+
+	builder := NewRequestBuilder(GET)
+	_, _ = builder.ResolveRequestURL(server.URL, "/instances", nil)
+	req, _ := builder.Build()
+
+	_, err = service.Request(req, nil)
+	assert.Nil(t, err)
+	_, err = service.Request(req, nil)
+	assert.Nil(t, err)
+
+	// The quota is used up, so a clone sharing the limiter fails fast.
+	detailedResponse, err := service.Clone().Request(req, nil)
+	assert.Nil(t, detailedResponse)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "client-side rate limit was exceeded")
+	sdkProblem, ok := err.(*SDKProblem)
+	assert.True(t, ok)
+	assert.Equal(t, "rate-limit-exceeded", sdkProblem.discriminator)
+	assert.Equal(t, 2, requests)
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+		RateLimiter:   NewRateLimiter(20, 1),
+	})
+	assert.Nil(t, err)
+
+	builder := NewRequestBuilder(GET)
+	_, _ = builder.ResolveRequestURL(server.URL, "/instances", nil)
+	req, _ := builder.Build()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err = service.Request(req, nil)
+		assert.Nil(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+
+	// A request whose Context is done stops waiting.
+	service.SetRateLimiter(NewRateLimiter(0.1, 1))
+	_, err = service.Request(req, nil)
+	assert.Nil(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = service.Request(req.WithContext(ctx), nil)
+	assert.NotNil(t, err)
+	sdkProblem, ok := err.(*SDKProblem)
+	assert.True(t, ok)
+	assert.Equal(t, "rate-limit-wait-canceled", sdkProblem.discriminator)
+}
@@ -0,0 +1,167 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+)
+
+// RequestAs invokes the specified HTTP request using "service" and returns the operation result
+// as an instance of T, decoded directly from the response body.
+//
+// Parameters:
+// service: the BaseService instance used to invoke the request
+//
+// req: the http.Request object that holds the request information
+//
+// unmarshaller: the generated Unmarshal<model>() function used to unmarshal each model instance.
+// The type parameter T determines how the response body is unmarshalled, and should be one of
+// the result types supported by UnmarshalModel(), without the outer pointer:
+//   - *<model> (e.g. RequestAs[*Foo](service, req, UnmarshalFoo))
+//   - []<model>, [][]<model>
+//   - map[string]<model>, map[string][]<model>
+//
+// If "unmarshaller" is nil, then the response body is simply decoded as JSON into an instance of T,
+// which is useful for primitive results (e.g. RequestAs[map[string]interface{}](service, req, nil)).
+//
+// Return values:
+// result: the operation result, or the zero value of T if the response contained no body
+//
+// detailedResponse: a DetailedResponse instance containing the status code, headers, etc.
+// For a successful operation, its Result field will also contain the operation result.
+//
+// err: a non-nil error object if an error occurred
+func RequestAs[T any](service *BaseService, req *http.Request, unmarshaller ModelUnmarshaller) (result T, detailedResponse *DetailedResponse, err error) {
+	detailedResponse, err = service.invoke(req, func(httpResponse *http.Response) (*DetailedResponse, error) {
+		return processModelResponse(httpResponse, &result, unmarshaller)
+	})
+	err = RepurposeSDKProblem(err, "")
+	return
+}
+
+// processModelResponse processes a successful response by unmarshalling the response body
+// into "result" (a pointer to an instance of the operation result type) using "unmarshaller".
+func processModelResponse(httpResponse *http.Response, result interface{}, unmarshaller ModelUnmarshaller) (detailedResponse *DetailedResponse, err error) {
+	detailedResponse, contentType := getDetailedResponseAndContentType(httpResponse)
+	if IsNil(httpResponse.Body) {
+		return
+	}
+
+	defer httpResponse.Body.Close() // #nosec G307
+	body := &recordingReader{reader: httpResponse.Body}
+	bufferedBody := bufio.NewReader(body)
+
+	// If the response body is empty, then skip any attempt to deserialize and just return.
+	first, err := firstNonSpaceByte(bufferedBody)
+	if err == io.EOF {
+		err = nil
+		return
+	}
+
+	// We can only unmarshal the operation result from a JSON response body.
+	if err == nil && !IsJSONMimeType(contentType) {
+		_, err = io.Copy(io.Discard, bufferedBody)
+		if err == nil {
+			detailedResponse.Result = body.recorded.Bytes()
+			err = fmt.Errorf(ERRORMSG_UNEXPECTED_RESPONSE, contentType, reflect.TypeOf(result).String())
+			err = SDKErrorf(err, "", "unparsable-result-type", getComponentInfo())
+			return
+		}
+	}
+
+	// Decode the response body as it is read, straight into the form expected by "unmarshaller".
+	if err == nil {
+		decoder := json.NewDecoder(bufferedBody)
+		if unmarshaller == nil {
+			err = decoder.Decode(result)
+		} else {
+			var rawInput interface{}
+			rawInput, err = decodeRawInput(decoder, first)
+			if err == nil {
+				err = UnmarshalModel(rawInput, "", result, unmarshaller)
+			}
+		}
+	}
+	if body.err != nil {
+		err = fmt.Errorf(ERRORMSG_READ_RESPONSE_BODY, body.err.Error())
+		err = SDKErrorf(err, "", "cant-read-success-res-body", getComponentInfo())
+		return
+	}
+	if err != nil {
+		// Return the response body in RawResult, along with an error.
+		err = fmt.Errorf(ERRORMSG_UNMARSHAL_RESPONSE_BODY, err.Error())
+		err = SDKErrorf(err, "", "res-body-unmarshal-error", getComponentInfo())
+		detailedResponse.RawResult = body.recorded.Bytes()
+		return
+	}
+
+	detailedResponse.Result = reflect.ValueOf(result).Elem().Interface()
+	return
+}
+
+// decodeRawInput decodes a JSON value from "decoder" into the unmarshal input source expected
+// by UnmarshalModel(): a []json.RawMessage for a JSON array (whose first byte is "first"),
+// or a map[string]json.RawMessage for a JSON object.
+func decodeRawInput(decoder *json.Decoder, first byte) (rawInput interface{}, err error) {
+	if first == '[' {
+		var rawSlice []json.RawMessage
+		err = decoder.Decode(&rawSlice)
+		rawInput = rawSlice
+	} else {
+		var rawMap map[string]json.RawMessage
+		err = decoder.Decode(&rawMap)
+		rawInput = rawMap
+	}
+	return
+}
+
+// firstNonSpaceByte returns the first byte of "reader" that isn't JSON whitespace, without consuming it.
+// It returns io.EOF if there is no such byte.
+func firstNonSpaceByte(reader *bufio.Reader) (byte, error) {
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, reader.UnreadByte()
+	}
+}
+
+// recordingReader is a reader that records the bytes read from "reader" (so that they can be
+// returned in RawResult if they can't be unmarshalled) and the first error other than io.EOF.
+type recordingReader struct {
+	reader   io.Reader
+	recorded bytes.Buffer
+	err      error
+}
+
+func (r *recordingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.recorded.Write(p[:n])
+	if err != nil && err != io.EOF && r.err == nil {
+		r.err = err
+	}
+	return n, err
+}
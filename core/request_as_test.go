@@ -0,0 +1,181 @@
+//go:build all || fast || basesvc
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Simulated "generated" unmarshal function for the Foo struct.
+func unmarshalFoo(m map[string]json.RawMessage, result interface{}) (err error) {
+	obj := new(Foo)
+	err = UnmarshalPrimitive(m, "name", &obj.Name)
+	if err != nil {
+		return
+	}
+	reflect.ValueOf(result).Elem().Set(reflect.ValueOf(obj))
+	return
+}
+
+func TestRequestAsModel(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusOK, APPLICATION_JSON, `{"name": "wonder woman"}`)
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	result, detailedResponse, err := RequestAs[*Foo](service, buildTestRequest(t, service, NewRequestBuilder(GET), ""), unmarshalFoo)
+	assert.Nil(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "wonder woman", *result.Name)
+	assert.Equal(t, http.StatusOK, detailedResponse.StatusCode)
+	assert.Equal(t, result, detailedResponse.Result)
+}
+
+func TestRequestAsModelSlice(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusOK, APPLICATION_JSON, ` [{"name": "wonder woman"}, {"name": "batman"}]`)
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	result, _, err := RequestAs[[]Foo](service, buildTestRequest(t, service, NewRequestBuilder(GET), ""), unmarshalFoo)
+	assert.Nil(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "batman", *result[1].Name)
+}
+
+func TestRequestAsModelMap(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusOK, APPLICATION_JSON, `{"hero": {"name": "wonder woman"}}`)
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	result, _, err := RequestAs[map[string]Foo](service, buildTestRequest(t, service, NewRequestBuilder(GET), ""), unmarshalFoo)
+	assert.Nil(t, err)
+	assert.Equal(t, "wonder woman", *result["hero"].Name)
+}
+
+func TestRequestAsPrimitive(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusOK, APPLICATION_JSON, `{"count": 38}`)
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	result, _, err := RequestAs[map[string]int64](service, buildTestRequest(t, service, NewRequestBuilder(GET), ""), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(38), result["count"])
+}
+
+func TestRequestAsNoBody(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusNoContent, "", "")
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	result, detailedResponse, err := RequestAs[*Foo](service, buildTestRequest(t, service, NewRequestBuilder(GET), ""), unmarshalFoo)
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, http.StatusNoContent, detailedResponse.StatusCode)
+	assert.Nil(t, detailedResponse.Result)
+}
+
+func TestRequestAsUnmarshalError(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusOK, APPLICATION_JSON, `{"name": 74}`)
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	result, detailedResponse, err := RequestAs[*Foo](service, buildTestRequest(t, service, NewRequestBuilder(GET), ""), unmarshalFoo)
+	assert.NotNil(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "error unmarshalling property 'name'")
+	assert.Equal(t, `{"name": 74}`, string(detailedResponse.RawResult))
+
+	sdkProblem, ok := err.(*SDKProblem)
+	assert.True(t, ok)
+	assert.Equal(t, "res-body-unmarshal-error", sdkProblem.discriminator)
+}
+
+func TestRequestAsDecodeError(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusOK, APPLICATION_JSON, `{"name": "wonder`)
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	_, detailedResponse, err := RequestAs[*Foo](service, buildTestRequest(t, service, NewRequestBuilder(GET), ""), unmarshalFoo)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "An error occurred while processing the HTTP response")
+	assert.Equal(t, `{"name": "wonder`, string(detailedResponse.RawResult))
+}
+
+func TestRequestAsNonJSONResponse(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusOK, "text/plain", "wonder woman")
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	result, detailedResponse, err := RequestAs[*Foo](service, buildTestRequest(t, service, NewRequestBuilder(GET), ""), unmarshalFoo)
+	assert.NotNil(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "Content-Type=text/plain, operation resultType=**core.Foo")
+	assert.Equal(t, []byte("wonder woman"), detailedResponse.Result)
+}
+
+func TestRequestAsErrorResponse(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusBadRequest, APPLICATION_JSON, `{"error": "bad request"}`)
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	result, detailedResponse, err := RequestAs[*Foo](service, buildTestRequest(t, service, NewRequestBuilder(GET), ""), unmarshalFoo)
+	assert.NotNil(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "bad request", err.Error())
+	assert.Equal(t, http.StatusBadRequest, detailedResponse.StatusCode)
+
+	sdkProblem, ok := err.(*SDKProblem)
+	assert.True(t, ok)
+	assert.NotNil(t, sdkProblem.httpProblem)
+}
+
+func TestRequestAsReadError(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	httpResponse := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{CONTENT_TYPE: []string{APPLICATION_JSON}},
+		Body:       io.NopCloser(io.MultiReader(strings.NewReader(`{"name": "wonder`), iotest.ErrReader(errors.New("connection reset")))),
+	}
+	var result *Foo
+	_, err := processModelResponse(httpResponse, &result, unmarshalFoo)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "connection reset")
+	assert.Equal(t, "cant-read-success-res-body", err.(*SDKProblem).discriminator)
+
+	// A body that contains only whitespace is empty.
+	httpResponse.Body = io.NopCloser(strings.NewReader(" \n"))
+	detailedResponse, err := processModelResponse(httpResponse, &result, unmarshalFoo)
+	assert.Nil(t, err)
+	assert.Nil(t, detailedResponse.Result)
+}
@@ -47,6 +47,7 @@ const (
 	APPLICATION_JSON        = "application/json"
 	CONTENT_DISPOSITION     = "Content-Disposition"
 	CONTENT_ENCODING        = "Content-Encoding"
+	CONTENT_LENGTH          = "Content-Length"
 	CONTENT_TYPE            = "Content-Type"
 	FORM_URL_ENCODED_HEADER = "application/x-www-form-urlencoded"
 
@@ -79,6 +80,20 @@ type RequestBuilder struct {
 	// value "gzip".
 	EnableGzipCompression bool
 
+	// Compression is an optional codec used to compress request bodies.
+	// If specified, it takes precedence over EnableGzipCompression: the Body field will be
+	// compressed by the codec (unless it is smaller than the codec's minimum size) and the
+	// "Content-Encoding" header will be added to the request with the codec's encoding.
+	Compression CompressionCodec
+
+	// Hedging is an optional HedgingPolicy that overrides the service's hedging policy
+	// (see ServiceOptions.Hedging) for the http.Request constructed by the Build() method.
+	Hedging *HedgingPolicy
+
+	// Timeouts are optional timeouts that override the service's timeouts
+	// (see ServiceOptions.Timeouts) for the http.Request constructed by the Build() method.
+	Timeouts RequestTimeouts
+
 	// RequestContext is an optional Context instance to be associated with the
 	// http.Request that is constructed by the Build() method.
 	ctx context.Context
@@ -379,9 +394,21 @@ func (requestBuilder *RequestBuilder) Build() (req *http.Request, err error) {
 		}
 	}
 
-	// If we have a request body and gzip is enabled, then wrap the body in a Gzip compression reader
+	// If we have a request body and a compression codec, then wrap the body in a compression reader
+	// and add the "Content-Encoding" request header, unless the body is too small to be compressed.
+	// Otherwise, if gzip is enabled, then wrap the body in a Gzip compression reader
 	// and add the "Content-Encoding: gzip" request header.
-	if !IsNil(requestBuilder.Body) && requestBuilder.EnableGzipCompression &&
+	if !IsNil(requestBuilder.Body) && !IsNil(requestBuilder.Compression) && requestBuilder.Header.Get(CONTENT_ENCODING) == "" {
+		newBody, compressed, err := compressBody(requestBuilder.Compression, requestBuilder.Body, bodyLength(requestBuilder.Body))
+		if err != nil {
+			err = RepurposeSDKProblem(err, "compression-error")
+			return nil, err
+		}
+		requestBuilder.Body = newBody
+		if compressed {
+			requestBuilder.Header.Add(CONTENT_ENCODING, requestBuilder.Compression.Encoding())
+		}
+	} else if !IsNil(requestBuilder.Body) && requestBuilder.EnableGzipCompression &&
 		!SliceContains(requestBuilder.Header[CONTENT_ENCODING], "gzip") {
 		newBody, err := NewGzipCompressionReader(requestBuilder.Body)
 		if err != nil {
@@ -424,6 +451,12 @@ func (requestBuilder *RequestBuilder) Build() (req *http.Request, err error) {
 	if !IsNil(requestBuilder.ctx) {
 		req = req.WithContext(requestBuilder.ctx)
 	}
+	if requestBuilder.Hedging != nil {
+		req = req.WithContext(WithHedgingPolicy(req.Context(), requestBuilder.Hedging))
+	}
+	if requestBuilder.Timeouts != (RequestTimeouts{}) {
+		req = req.WithContext(WithRequestTimeouts(req.Context(), requestBuilder.Timeouts))
+	}
 
 	return
 }
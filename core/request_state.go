@@ -0,0 +1,189 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// requestState holds information about a request being processed by a BaseService
+// that must be available to the retryable client's retry and backoff policies,
+// which only have access to the request's Context.
+type requestState struct {
+	// noRetry indicates that the request must not be retried, even if
+	// the retry policy would otherwise retry it.
+	noRetry bool
+
+	// method and host are the method of the request and the host to which it is sent.
+	method string
+	host   string
+
+	// start is the time at which the request was first sent.
+	start time.Time
+
+	// retryPolicy is the (optional) RetryPolicy configured on the service that sent the request.
+	retryPolicy *RetryPolicy
+
+	// circuitBreaker, retryBudget and rateLimiter are the (optional) CircuitBreaker,
+	// RetryBudget and RateLimiter configured on the service that sent the request.
+	circuitBreaker *CircuitBreaker
+	retryBudget    *RetryBudget
+	rateLimiter    *RateLimiter
+
+	// maxRetries is the maximum number of retries that may be performed by the
+	// retryable client, and attempts is the number of attempts checked so far.
+	maxRetries int
+	attempts   int
+
+	// history describes the attempts checked so far. The current attempt started at
+	// attemptStart, and the previous one ended at attemptEnd with a response that
+	// had a "Retry-After" header if retryAfter is true.
+	history      []RetryAttempt
+	attemptStart time.Time
+	attemptEnd   time.Time
+	retryAfter   bool
+
+	// retryWait is the wait time before the previous retry, on which the wait time
+	// before the next one depends if the RetryPolicy uses JitterDecorrelated.
+	retryWait time.Duration
+
+	// hedges is the number of duplicate requests sent by a HedgingPolicy.
+	hedges int
+
+	// attemptSpan is the span that describes the current attempt, and traceParent
+	// indicates whether or not each attempt carries a "traceparent" header.
+	attemptSpan Span
+	traceParent bool
+}
+
+// clone returns a copy of the request's state, without its history,
+// to be used by a duplicate request sent by a HedgingPolicy.
+func (state *requestState) clone() *requestState {
+	c := *state
+	c.history = nil
+	return &c
+}
+
+// startAttempt is invoked before each attempt to send "req".
+func (state *requestState) startAttempt(req *http.Request) {
+	now := time.Now()
+	if n := len(state.history); n > 0 {
+		state.history[n-1].Wait = now.Sub(state.attemptEnd)
+		state.history[n-1].RetryAfter = state.retryAfter
+	}
+	state.attemptStart = now
+
+	_, state.attemptSpan = startRequestSpan(req, SPAN_NAME_REQUEST_ATTEMPT)
+	state.attemptSpan.SetAttribute(SPAN_ATTR_HTTP_RESEND, len(state.history))
+	if state.traceParent {
+		injectTraceParent(req, state.attemptSpan)
+	}
+}
+
+// endAttempt adds the outcome of the current attempt to the request's history.
+func (state *requestState) endAttempt(resp *http.Response, err error) {
+	now := time.Now()
+	start := state.attemptStart
+	if start.IsZero() {
+		// The retryable client wasn't configured to tell us when the attempt started.
+		start = state.attemptEnd
+		if start.IsZero() {
+			start = state.start
+		}
+	}
+
+	attempt := RetryAttempt{
+		Attempt:  state.attempts,
+		Duration: now.Sub(start),
+	}
+	if err != nil {
+		attempt.Error = RedactSecrets(err.Error())
+	} else if resp != nil {
+		attempt.StatusCode = resp.StatusCode
+	}
+	state.history = append(state.history, attempt)
+
+	if state.attemptSpan != nil {
+		if attempt.StatusCode != 0 {
+			state.attemptSpan.SetAttribute(SPAN_ATTR_HTTP_STATUS_CODE, attempt.StatusCode)
+		}
+		endSpan(state.attemptSpan, err)
+		state.attemptSpan = nil
+	}
+
+	state.attemptStart = time.Time{}
+	state.attemptEnd = now
+	state.retryAfter = err == nil && resp != nil && resp.Header.Get(headerNameRetryAfter) != ""
+}
+
+// checkAttempt is invoked by the retry policy with the outcome of each attempt.
+// It records the attempt, updates the circuit breaker and rate limiter, and returns false if a retry that
+// would otherwise be performed must be cancelled.
+func (state *requestState) checkAttempt(ctx context.Context, resp *http.Response, err error, retry bool) bool {
+	state.attempts++
+	state.endAttempt(resp, err)
+	if state.circuitBreaker != nil {
+		state.circuitBreaker.record(state.host, resp, attemptError(ctx, err))
+	}
+	if state.rateLimiter != nil {
+		state.rateLimiter.update(resp)
+	}
+	if !retry {
+		return true
+	}
+
+	if state.noRetry {
+		GetLogger().Debug("No retry, the request has a non-idempotent method and no idempotency key\n")
+		return false
+	}
+	if state.circuitBreaker != nil && state.circuitBreaker.State(state.host) == CircuitOpen {
+		GetLogger().Debug("No retry, the circuit breaker for host '%s' is open\n", state.host)
+		return false
+	}
+	// The final attempt is also checked, but won't be retried, so it needs neither
+	// a retry token nor permission from the rate limiter.
+	if state.attempts > state.maxRetries {
+		return true
+	}
+	if state.retryBudget != nil && !state.retryBudget.withdraw() {
+		GetLogger().Debug("No retry, the retry budget is exhausted\n")
+		return false
+	}
+	if state.rateLimiter != nil {
+		if limitErr := state.rateLimiter.wait(ctx, true); limitErr != nil {
+			GetLogger().Debug("No retry, %s\n", limitErr.Error())
+			return false
+		}
+	}
+	return true
+}
+
+type requestStateKey struct{}
+
+// withRequestState returns a copy of "ctx" that carries "state".
+func withRequestState(ctx context.Context, state *requestState) context.Context {
+	return context.WithValue(ctx, requestStateKey{}, state)
+}
+
+// getRequestState returns the requestState carried by "ctx", or nil if there is none.
+func getRequestState(ctx context.Context) *requestState {
+	if ctx == nil {
+		return nil
+	}
+	state, _ := ctx.Value(requestStateKey{}).(*requestState)
+	return state
+}
@@ -0,0 +1,400 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultResponseCacheSize is the default maximum number of entries
+	// held by the storage returned by NewLRUResponseCacheStorage().
+	DefaultResponseCacheSize = 256
+
+	// DefaultResponseCacheMaxBodySize is the default size of the largest
+	// response body that will be stored by a ResponseCache (1MB).
+	DefaultResponseCacheMaxBodySize = 1024 * 1024
+
+	headerNameCacheControl    = "Cache-Control"
+	headerNameETag            = "ETag"
+	headerNameLastModified    = "Last-Modified"
+	headerNameIfNoneMatch     = "If-None-Match"
+	headerNameIfModifiedSince = "If-Modified-Since"
+	headerNameExpires         = "Expires"
+	headerNameAge             = "Age"
+	headerNameVary            = "Vary"
+)
+
+// defaultResponseCacheKeyHeaders are the request headers that are included in the cache key
+// by default, so that responses are never shared between different credentials or
+// representations of a resource.
+var defaultResponseCacheKeyHeaders = []string{"Accept", "Accept-Encoding", "Accept-Language", "Authorization"}
+
+// ResponseCacheEntry is a GET response stored by a ResponseCache.
+type ResponseCacheEntry struct {
+	// The status code, headers and body of the response, as received from the server.
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+
+	// The response's validators, used to revalidate the entry with a conditional request.
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+
+	// The time at which the entry becomes stale. A zero value means
+	// the entry must be revalidated each time it is used.
+	Expires time.Time `json:"expires,omitempty"`
+
+	// The values of the request headers listed in the response's "Vary" header.
+	Vary map[string]string `json:"vary,omitempty"`
+}
+
+// ResponseCacheStorage is implemented by the stores that hold the entries of a ResponseCache.
+// Implementations must be safe for concurrent use.
+type ResponseCacheStorage interface {
+	// Get returns the entry stored with "key", if any.
+	Get(key string) (*ResponseCacheEntry, bool)
+
+	// Set stores "entry" with "key", replacing any existing entry.
+	Set(key string, entry *ResponseCacheEntry)
+
+	// Delete removes the entry stored with "key", if any.
+	Delete(key string)
+}
+
+// lruResponseCacheStorage is an in-memory ResponseCacheStorage that evicts
+// the least recently used entry when full.
+type lruResponseCacheStorage struct {
+	mutex      sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+type lruItem struct {
+	key   string
+	entry *ResponseCacheEntry
+}
+
+// NewLRUResponseCacheStorage returns an in-memory ResponseCacheStorage that holds
+// at most "maxEntries" entries (or DefaultResponseCacheSize if "maxEntries" is not positive),
+// evicting the least recently used entry when full.
+func NewLRUResponseCacheStorage(maxEntries int) ResponseCacheStorage {
+	if maxEntries <= 0 {
+		maxEntries = DefaultResponseCacheSize
+	}
+	return &lruResponseCacheStorage{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+func (storage *lruResponseCacheStorage) Get(key string) (*ResponseCacheEntry, bool) {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+
+	if element, ok := storage.entries[key]; ok {
+		storage.order.MoveToFront(element)
+		return element.Value.(*lruItem).entry, true
+	}
+	return nil, false
+}
+
+func (storage *lruResponseCacheStorage) Set(key string, entry *ResponseCacheEntry) {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+
+	if element, ok := storage.entries[key]; ok {
+		element.Value.(*lruItem).entry = entry
+		storage.order.MoveToFront(element)
+		return
+	}
+
+	storage.entries[key] = storage.order.PushFront(&lruItem{key: key, entry: entry})
+	if storage.order.Len() > storage.maxEntries {
+		oldest := storage.order.Back()
+		storage.order.Remove(oldest)
+		delete(storage.entries, oldest.Value.(*lruItem).key)
+	}
+}
+
+func (storage *lruResponseCacheStorage) Delete(key string) {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+
+	if element, ok := storage.entries[key]; ok {
+		storage.order.Remove(element)
+		delete(storage.entries, key)
+	}
+}
+
+// ResponseCache is an HTTP cache for the GET requests processed by a BaseService
+// (see ServiceOptions.ResponseCache). Successful (200) responses are stored according
+// to their "Cache-Control" (or "Expires") header, and are served without contacting the
+// server while they are fresh. Once stale, an entry is revalidated with a conditional
+// request ("If-None-Match" and/or "If-Modified-Since") and its body is served again if
+// the server responds with 304 (Not Modified).
+//
+// Responses are stored only if they have a freshness lifetime or a validator
+// ("ETag" or "Last-Modified"), and never if they contain "Cache-Control: no-store".
+// Successful requests with an unsafe method (e.g. PUT or DELETE) invalidate the entry
+// for the same URL.
+type ResponseCache struct {
+	storage     ResponseCacheStorage
+	keyHeaders  []string
+	maxBodySize int64
+
+	// now returns the current time; it can be replaced by tests.
+	now func() time.Time
+}
+
+// NewResponseCache returns a new ResponseCache that holds its entries in "storage",
+// or in the storage returned by NewLRUResponseCacheStorage(DefaultResponseCacheSize)
+// if "storage" is nil.
+func NewResponseCache(storage ResponseCacheStorage) *ResponseCache {
+	if IsNil(storage) {
+		storage = NewLRUResponseCacheStorage(DefaultResponseCacheSize)
+	}
+	return &ResponseCache{
+		storage:     storage,
+		keyHeaders:  defaultResponseCacheKeyHeaders,
+		maxBodySize: DefaultResponseCacheMaxBodySize,
+		now:         time.Now,
+	}
+}
+
+// WithKeyHeaders sets the names of the request headers whose values are combined with the
+// request URL to form the cache key. By default, the "Accept", "Accept-Encoding",
+// "Accept-Language" and "Authorization" headers are used so that a response is only
+// served to requests made with the same credentials.
+func (cache *ResponseCache) WithKeyHeaders(headers ...string) *ResponseCache {
+	cache.keyHeaders = headers
+	return cache
+}
+
+// WithMaxBodySize sets the size of the largest response body that will be stored.
+func (cache *ResponseCache) WithMaxBodySize(maxBodySize int64) *ResponseCache {
+	cache.maxBodySize = maxBodySize
+	return cache
+}
+
+// GetStorage returns the cache's storage.
+func (cache *ResponseCache) GetStorage() ResponseCacheStorage {
+	return cache.storage
+}
+
+// send uses "send" to send the GET request "req", unless a fresh response is cached.
+// The returned "fromCache" flag indicates the response body was served from the cache.
+func (cache *ResponseCache) send(req *http.Request,
+	send func(*http.Request) (*http.Response, error)) (httpResponse *http.Response, fromCache bool, err error) {
+	// Requests that manage their own validators, ask for part of a resource or
+	// forbid caching are sent as-is.
+	requestDirectives := parseCacheControl(req.Header.Get(headerNameCacheControl))
+	if _, noStore := requestDirectives["no-store"]; noStore || req.Header.Get(headerNameIfNoneMatch) != "" ||
+		req.Header.Get(headerNameIfModifiedSince) != "" || req.Header.Get("Range") != "" {
+		httpResponse, err = send(req)
+		return
+	}
+
+	key := cache.key(req)
+	entry, found := cache.storage.Get(key)
+	if found && !entry.matchesVary(req) {
+		found = false
+	}
+
+	if found {
+		_, noCache := requestDirectives["no-cache"]
+		if !noCache && requestDirectives["max-age"] != "0" && cache.now().Before(entry.Expires) {
+			GetLogger().Debug("Serving fresh response from cache for GET %s", req.URL.Redacted())
+			return entry.toResponse(req), true, nil
+		}
+
+		// The entry is stale, so ask the server whether it has been modified.
+		if entry.ETag != "" {
+			req.Header.Set(headerNameIfNoneMatch, entry.ETag)
+			defer req.Header.Del(headerNameIfNoneMatch)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set(headerNameIfModifiedSince, entry.LastModified)
+			defer req.Header.Del(headerNameIfModifiedSince)
+		}
+	}
+
+	httpResponse, err = send(req)
+	if err != nil {
+		return
+	}
+
+	if found && httpResponse.StatusCode == http.StatusNotModified {
+		GetLogger().Debug("Serving revalidated response from cache for GET %s", req.URL.Redacted())
+		if !IsNil(httpResponse.Body) {
+			_, _ = io.Copy(io.Discard, httpResponse.Body)
+			_ = httpResponse.Body.Close()
+		}
+
+		// Update the stored entry with the headers (and freshness) from the 304 response.
+		updated := *entry
+		updated.Header = entry.Header.Clone()
+		for name, values := range httpResponse.Header {
+			if !strings.EqualFold(name, CONTENT_LENGTH) {
+				updated.Header[name] = values
+			}
+		}
+		updated.Expires = cache.expires(updated.Header)
+		cache.storage.Set(key, &updated)
+		return updated.toResponse(req), true, nil
+	}
+
+	if httpResponse.StatusCode == http.StatusOK {
+		cache.store(key, req, httpResponse)
+	}
+	return
+}
+
+// store adds "httpResponse" to the cache if it is cacheable.
+// The response body is read into memory and replaced with a reader that delivers the same bytes.
+func (cache *ResponseCache) store(key string, req *http.Request, httpResponse *http.Response) {
+	header := httpResponse.Header
+	if _, noStore := parseCacheControl(header.Get(headerNameCacheControl))["no-store"]; noStore ||
+		header.Get(headerNameVary) == "*" || IsNil(httpResponse.Body) {
+		return
+	}
+
+	entry := &ResponseCacheEntry{
+		StatusCode:   httpResponse.StatusCode,
+		Header:       header.Clone(),
+		ETag:         header.Get(headerNameETag),
+		LastModified: header.Get(headerNameLastModified),
+		Expires:      cache.expires(header),
+	}
+	if entry.ETag == "" && entry.LastModified == "" && !cache.now().Before(entry.Expires) {
+		return
+	}
+
+	if vary := header.Values(headerNameVary); len(vary) > 0 {
+		entry.Vary = make(map[string]string)
+		for _, names := range vary {
+			for _, name := range strings.Split(names, ",") {
+				name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+				entry.Vary[name] = strings.Join(req.Header.Values(name), ",")
+			}
+		}
+	}
+
+	// Read the body, unless it turns out to be too large to store.
+	body := httpResponse.Body
+	data, err := io.ReadAll(io.LimitReader(body, cache.maxBodySize+1))
+	if err != nil || int64(len(data)) > cache.maxBodySize {
+		// Deliver the bytes we've already read, followed by the rest of the body (or the read error).
+		httpResponse.Body = &readCloser{Reader: io.MultiReader(bytes.NewReader(data), body), Closer: body}
+		return
+	}
+	httpResponse.Body = &readCloser{Reader: bytes.NewReader(data), Closer: body}
+
+	entry.Body = data
+	cache.storage.Set(key, entry)
+	GetLogger().Debug("Stored response in cache for GET %s", req.URL.Redacted())
+}
+
+// invalidate removes the entry for the URL of "req" after a successful request with an unsafe method.
+func (cache *ResponseCache) invalidate(req *http.Request) {
+	cache.storage.Delete(cache.key(req))
+}
+
+// key returns the cache key for "req", which is a hash of the request URL and
+// the values of the cache's key headers.
+func (cache *ResponseCache) key(req *http.Request) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.URL.String()))
+	for _, name := range cache.keyHeaders {
+		hash.Write([]byte("\n" + http.CanonicalHeaderKey(name) + ":" + strings.Join(req.Header.Values(name), ",")))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// expires returns the time at which a response with the specified headers becomes stale.
+func (cache *ResponseCache) expires(header http.Header) time.Time {
+	directives := parseCacheControl(header.Get(headerNameCacheControl))
+	if _, noCache := directives["no-cache"]; noCache {
+		return time.Time{}
+	}
+
+	now := cache.now()
+	if maxAge, ok := directives["max-age"]; ok {
+		seconds, err := strconv.ParseInt(maxAge, 10, 64)
+		if err != nil || seconds <= 0 {
+			return time.Time{}
+		}
+		if age, err := strconv.ParseInt(header.Get(headerNameAge), 10, 64); err == nil && age > 0 {
+			seconds -= age
+		}
+		return now.Add(time.Duration(seconds) * time.Second)
+	}
+
+	if expires := header.Get(headerNameExpires); expires != "" {
+		if t, err := http.ParseTime(expires); err == nil && t.After(now) {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// matchesVary returns true if "req" has the same values as the original request
+// for the headers listed in the response's "Vary" header.
+func (entry *ResponseCacheEntry) matchesVary(req *http.Request) bool {
+	for name, value := range entry.Vary {
+		if strings.Join(req.Header.Values(name), ",") != value {
+			return false
+		}
+	}
+	return true
+}
+
+// toResponse returns a new http.Response containing the entry's status code, headers and body.
+func (entry *ResponseCacheEntry) toResponse(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(entry.StatusCode) + " " + http.StatusText(entry.StatusCode),
+		StatusCode:    entry.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+		Request:       req,
+	}
+}
+
+// parseCacheControl parses the directives contained in a "Cache-Control" header value.
+func parseCacheControl(value string) map[string]string {
+	directives := make(map[string]string)
+	for _, directive := range strings.Split(value, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		if name != "" {
+			directives[strings.ToLower(name)] = strings.Trim(arg, `"`)
+		}
+	}
+	return directives
+}
@@ -0,0 +1,306 @@
+//go:build all || fast || basesvc
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// cacheTestServer is a server for a single resource whose name and response headers can be changed.
+type cacheTestServer struct {
+	*httptest.Server
+	name         string
+	etag         string
+	lastModified string
+	cacheControl string
+	requests     []*http.Request
+}
+
+func startCacheTestServer(cacheControl string) *cacheTestServer {
+	server := &cacheTestServer{
+		name:         "resource-1",
+		etag:         `"v1"`,
+		cacheControl: cacheControl,
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.requests = append(server.requests, r)
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if server.cacheControl != "" {
+			w.Header().Set("Cache-Control", server.cacheControl)
+		}
+		if server.etag != "" {
+			w.Header().Set("ETag", server.etag)
+			if r.Header.Get("If-None-Match") == server.etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		if server.lastModified != "" {
+			w.Header().Set("Last-Modified", server.lastModified)
+			if r.Header.Get("If-Modified-Since") == server.lastModified {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", APPLICATION_JSON)
+		fmt.Fprintf(w, `{"name": "%s"}`, server.name)
+	}))
+	return server
+}
+
+// getCachedFoo invokes a GET request for the test resource.
+func getCachedFoo(t *testing.T, service *BaseService, headers map[string]string) (*Foo, *DetailedResponse) {
+	builder := NewRequestBuilder(GET)
+	for name, value := range headers {
+		builder.AddHeader(name, value)
+	}
+	var foo *Foo
+	detailedResponse, err := invokeTestRequest(t, service, builder, "/resources/1", &foo)
+	assert.Nil(t, err)
+	assert.NotNil(t, foo)
+	return foo, detailedResponse
+}
+
+func TestResponseCacheFresh(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startCacheTestServer("max-age=60")
+	defer server.Close()
+
+	cache := NewResponseCache(nil)
+	service := newTestService(t, &ServiceOptions{URL: server.URL, ResponseCache: cache})
+	assert.Equal(t, cache, service.GetResponseCache())
+
+	foo, detailedResponse := getCachedFoo(t, service, nil)
+	assert.Equal(t, "resource-1", *foo.Name)
+	assert.False(t, detailedResponse.FromCache)
+
+	// The response is fresh, so it's served without contacting the server.
+	server.name = "resource-2"
+	foo, detailedResponse = getCachedFoo(t, service, nil)
+	assert.Equal(t, "resource-1", *foo.Name)
+	assert.True(t, detailedResponse.FromCache)
+	assert.Equal(t, http.StatusOK, detailedResponse.StatusCode)
+	assert.Equal(t, `"v1"`, detailedResponse.Headers.Get("ETag"))
+	assert.Len(t, server.requests, 1)
+
+	// Once stale, the response is revalidated and the new version is returned.
+	cache.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	server.etag = `"v2"`
+	foo, detailedResponse = getCachedFoo(t, service, nil)
+	assert.Equal(t, "resource-2", *foo.Name)
+	assert.False(t, detailedResponse.FromCache)
+	assert.Len(t, server.requests, 2)
+	assert.Equal(t, `"v1"`, server.requests[1].Header.Get("If-None-Match"))
+}
+
+func TestResponseCacheRevalidate(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startCacheTestServer("no-cache")
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL, ResponseCache: NewResponseCache(NewLRUResponseCacheStorage(10))})
+
+	_, detailedResponse := getCachedFoo(t, service, nil)
+	assert.False(t, detailedResponse.FromCache)
+
+	// The server responds with 304, so the cached body is used.
+	foo, detailedResponse := getCachedFoo(t, service, nil)
+	assert.Equal(t, "resource-1", *foo.Name)
+	assert.True(t, detailedResponse.FromCache)
+	assert.Equal(t, http.StatusOK, detailedResponse.StatusCode)
+	assert.Len(t, server.requests, 2)
+	assert.Equal(t, `"v1"`, server.requests[1].Header.Get("If-None-Match"))
+}
+
+func TestResponseCacheLastModified(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startCacheTestServer("")
+	server.etag = ""
+	server.lastModified = "Mon, 12 Oct 2026 10:00:00 GMT"
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL, ResponseCache: NewResponseCache(nil)})
+	getCachedFoo(t, service, nil)
+	_, detailedResponse := getCachedFoo(t, service, nil)
+	assert.True(t, detailedResponse.FromCache)
+	assert.Equal(t, "Mon, 12 Oct 2026 10:00:00 GMT", server.requests[1].Header.Get("If-Modified-Since"))
+}
+
+func TestResponseCacheNotStored(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+
+	// "no-store" responses and responses without freshness or validators are not stored.
+	for _, cacheControl := range []string{"no-store", "max-age=0"} {
+		server := startCacheTestServer(cacheControl)
+		if cacheControl == "max-age=0" {
+			server.etag = ""
+		}
+		service := newTestService(t, &ServiceOptions{URL: server.URL, ResponseCache: NewResponseCache(nil)})
+
+		getCachedFoo(t, service, nil)
+		_, detailedResponse := getCachedFoo(t, service, nil)
+		assert.False(t, detailedResponse.FromCache)
+		assert.Len(t, server.requests, 2)
+		assert.Empty(t, server.requests[1].Header.Get("If-None-Match"))
+		server.Close()
+	}
+}
+
+func TestResponseCacheRequestDirectives(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startCacheTestServer("max-age=60")
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL, ResponseCache: NewResponseCache(nil)})
+	getCachedFoo(t, service, nil)
+
+	// "no-cache" on the request forces revalidation of a fresh response.
+	_, detailedResponse := getCachedFoo(t, service, map[string]string{"Cache-Control": "no-cache"})
+	assert.True(t, detailedResponse.FromCache)
+	assert.Len(t, server.requests, 2)
+
+	// "no-store" on the request bypasses the cache.
+	_, detailedResponse = getCachedFoo(t, service, map[string]string{"Cache-Control": "no-store"})
+	assert.False(t, detailedResponse.FromCache)
+	assert.Len(t, server.requests, 3)
+	assert.Empty(t, server.requests[2].Header.Get("If-None-Match"))
+}
+
+func TestResponseCacheKeyHeaders(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startCacheTestServer("max-age=60")
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL, ResponseCache: NewResponseCache(nil)})
+
+	// Responses are not shared between different credentials.
+	getCachedFoo(t, service, map[string]string{"Authorization": "Bearer token-1"})
+	_, detailedResponse := getCachedFoo(t, service, map[string]string{"Authorization": "Bearer token-2"})
+	assert.False(t, detailedResponse.FromCache)
+	_, detailedResponse = getCachedFoo(t, service, map[string]string{"Authorization": "Bearer token-1"})
+	assert.True(t, detailedResponse.FromCache)
+	assert.Len(t, server.requests, 2)
+
+	// ...unless the Authorization header isn't part of the key.
+	service.GetResponseCache().WithKeyHeaders("Accept")
+	getCachedFoo(t, service, map[string]string{"Authorization": "Bearer token-1"})
+	_, detailedResponse = getCachedFoo(t, service, map[string]string{"Authorization": "Bearer token-2"})
+	assert.True(t, detailedResponse.FromCache)
+	assert.Len(t, server.requests, 3)
+}
+
+func TestResponseCacheVary(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Vary", "X-Tenant")
+		w.Header().Set("Content-Type", APPLICATION_JSON)
+		fmt.Fprintf(w, `{"name": "%s"}`, r.Header.Get("X-Tenant"))
+	}))
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL, ResponseCache: NewResponseCache(nil)})
+	foo, _ := getCachedFoo(t, service, map[string]string{"X-Tenant": "a"})
+	assert.Equal(t, "a", *foo.Name)
+	foo, detailedResponse := getCachedFoo(t, service, map[string]string{"X-Tenant": "b"})
+	assert.Equal(t, "b", *foo.Name)
+	assert.False(t, detailedResponse.FromCache)
+	foo, detailedResponse = getCachedFoo(t, service, map[string]string{"X-Tenant": "b"})
+	assert.Equal(t, "b", *foo.Name)
+	assert.True(t, detailedResponse.FromCache)
+	assert.Equal(t, 2, requests)
+}
+
+func TestResponseCacheInvalidation(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startCacheTestServer("max-age=60")
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL, ResponseCache: NewResponseCache(nil)})
+	getCachedFoo(t, service, nil)
+
+	builder := NewRequestBuilder(PUT)
+	_, _ = builder.ResolveRequestURL(server.URL, "/resources/1", nil)
+	_, _ = builder.SetBodyContentJSON(map[string]string{"name": "resource-2"})
+	req, _ := builder.Build()
+	_, err := service.Request(req, nil)
+	assert.Nil(t, err)
+
+	server.name = "resource-2"
+	foo, detailedResponse := getCachedFoo(t, service, nil)
+	assert.Equal(t, "resource-2", *foo.Name)
+	assert.False(t, detailedResponse.FromCache)
+	assert.Len(t, server.requests, 3)
+}
+
+func TestResponseCacheMaxBodySize(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startCacheTestServer("max-age=60")
+	server.name = strings.Repeat("x", 100)
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL, ResponseCache: NewResponseCache(nil).WithMaxBodySize(50)})
+	foo, _ := getCachedFoo(t, service, nil)
+	assert.Equal(t, server.name, *foo.Name)
+	foo, detailedResponse := getCachedFoo(t, service, nil)
+	assert.Equal(t, server.name, *foo.Name)
+	assert.False(t, detailedResponse.FromCache)
+	assert.Len(t, server.requests, 2)
+}
+
+func TestLRUResponseCacheStorage(t *testing.T) {
+	storage := NewLRUResponseCacheStorage(2)
+	storage.Set("a", &ResponseCacheEntry{ETag: "a"})
+	storage.Set("b", &ResponseCacheEntry{ETag: "b"})
+
+	// Using "a" makes "b" the least recently used entry.
+	entry, ok := storage.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "a", entry.ETag)
+
+	storage.Set("c", &ResponseCacheEntry{ETag: "c"})
+	_, ok = storage.Get("b")
+	assert.False(t, ok)
+	_, ok = storage.Get("a")
+	assert.True(t, ok)
+
+	storage.Set("c", &ResponseCacheEntry{ETag: "c2"})
+	entry, _ = storage.Get("c")
+	assert.Equal(t, "c2", entry.ETag)
+
+	storage.Delete("c")
+	_, ok = storage.Get("c")
+	assert.False(t, ok)
+}
+
+func TestParseCacheControl(t *testing.T) {
+	directives := parseCacheControl(`max-age=60, No-Cache, private="x-foo"`)
+	assert.Equal(t, map[string]string{"max-age": "60", "no-cache": "", "private": "x-foo"}, directives)
+	assert.Empty(t, parseCacheControl(""))
+}
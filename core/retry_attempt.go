@@ -0,0 +1,52 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"net/http"
+	"time"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+)
+
+// RetryAttempt describes one of the attempts made to send a request
+// by a service with automatic retries enabled.
+type RetryAttempt struct {
+	// The number of the attempt (1 for the initial attempt).
+	Attempt int `yaml:"attempt"`
+
+	// The status code of the response received, or 0 if the request could not be sent.
+	StatusCode int `yaml:"status_code,omitempty"`
+
+	// The (redacted) error that prevented the request from being sent, if any.
+	Error string `yaml:"error,omitempty"`
+
+	// The time taken by the attempt.
+	Duration time.Duration `yaml:"duration"`
+
+	// The time waited before the next attempt (0 for the last attempt).
+	Wait time.Duration `yaml:"wait,omitempty"`
+
+	// RetryAfter is true if the wait was determined by the response's "Retry-After" header.
+	RetryAfter bool `yaml:"retry_after,omitempty"`
+}
+
+// retryAttemptHook is the retryable client's RequestLogHook. It is invoked before each
+// attempt to send a request, and starts the RetryAttempt (and span) that describes the attempt.
+func retryAttemptHook(_ retryablehttp.Logger, req *http.Request, _ int) {
+	if state := getRequestState(req.Context()); state != nil {
+		state.startAttempt(req)
+	}
+}
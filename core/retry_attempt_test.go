@@ -0,0 +1,141 @@
+//go:build all || fast || basesvc || retries
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// debugMapKeys returns the keys of the debug ordered maps of "problem".
+func debugMapKeys(problem OrderableProblem) []string {
+	var keys []string
+	for _, item := range problem.GetDebugOrderedMaps().GetMaps() {
+		keys = append(keys, item.Key.(string))
+	}
+	return keys
+}
+
+func TestRetryAttemptsSuccess(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			time.Sleep(5 * time.Millisecond)
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	service.EnableRetries(2, 10*time.Millisecond)
+	detailedResponse, err := invokeTestRequest(t, service, NewRequestBuilder(GET), "/instances", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, detailedResponse.StatusCode)
+
+	attempts := detailedResponse.Attempts
+	assert.Len(t, attempts, 3)
+	for i, attempt := range attempts {
+		assert.Equal(t, i+1, attempt.Attempt)
+		assert.Empty(t, attempt.Error)
+		assert.Greater(t, attempt.Duration, time.Duration(0))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, attempts[0].StatusCode)
+	assert.True(t, attempts[0].RetryAfter)
+	assert.Equal(t, http.StatusBadGateway, attempts[1].StatusCode)
+	assert.False(t, attempts[1].RetryAfter)
+	assert.GreaterOrEqual(t, attempts[1].Duration, 5*time.Millisecond)
+	assert.GreaterOrEqual(t, attempts[1].Wait, 10*time.Millisecond)
+	assert.Equal(t, http.StatusOK, attempts[2].StatusCode)
+	assert.Zero(t, attempts[2].Wait)
+
+	// Without retries, there is no history.
+	service.DisableRetries()
+	detailedResponse, err = invokeTestRequest(t, service, NewRequestBuilder(GET), "/instances", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, detailedResponse.Attempts)
+}
+
+func TestRetryAttemptsErrorResponse(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusServiceUnavailable, "", "")
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	service.EnableRetries(2, 10*time.Millisecond)
+	detailedResponse, err := invokeTestRequest(t, service, NewRequestBuilder(GET), "/instances", nil)
+	assert.NotNil(t, err)
+	assert.Len(t, detailedResponse.Attempts, 3)
+
+	sdkProblem, ok := err.(*SDKProblem)
+	assert.True(t, ok)
+	assert.Contains(t, debugMapKeys(sdkProblem), "attempts")
+
+	// The HTTPProblem shows the attempts separately from the response.
+	httpProblem := sdkProblem.httpProblem
+	assert.NotNil(t, httpProblem)
+	maps := httpProblem.GetDebugOrderedMaps().GetMaps()
+	assert.Equal(t, "response", maps[len(maps)-2].Key)
+	assert.Nil(t, maps[len(maps)-2].Value.(DetailedResponse).Attempts)
+	assert.Equal(t, "attempts", maps[len(maps)-1].Key)
+	assert.Equal(t, detailedResponse.Attempts, maps[len(maps)-1].Value)
+	assert.Contains(t, httpProblem.GetDebugMessage(), "status_code: 503")
+	assert.Contains(t, httpProblem.GetDebugMessage(), "attempt: 3")
+	assert.NotContains(t, httpProblem.GetConsoleMessage(), "attempts")
+}
+
+func TestRetryAttemptsConnectionError(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := startTestServer(http.StatusOK, "", "")
+	server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	service.EnableRetries(2, 10*time.Millisecond)
+	detailedResponse, err := invokeTestRequest(t, service, NewRequestBuilder(GET), "/instances", nil)
+	assert.Nil(t, detailedResponse)
+	assert.NotNil(t, err)
+
+	sdkProblem, ok := err.(*SDKProblem)
+	assert.True(t, ok)
+	assert.Len(t, sdkProblem.attempts, 3)
+	for _, attempt := range sdkProblem.attempts {
+		assert.Zero(t, attempt.StatusCode)
+		assert.Contains(t, attempt.Error, "connection refused")
+	}
+	assert.Contains(t, debugMapKeys(sdkProblem), "attempts")
+	assert.Contains(t, sdkProblem.GetDebugMessage(), "connection refused")
+
+	// The attempts are kept when an SDK wraps the problem.
+	wrapped := SDKErrorf(err, "", "wrapped", NewProblemComponent("my-sdk", "1.0.0"))
+	assert.Equal(t, sdkProblem.attempts, wrapped.attempts)
+	assert.Contains(t, debugMapKeys(wrapped), "attempts")
+
+	wrapped = SDKErrorf(errors.New("other error"), "", "unrelated", NewProblemComponent("my-sdk", "1.0.0"))
+	assert.NotContains(t, debugMapKeys(wrapped), "attempts")
+}
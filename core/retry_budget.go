@@ -0,0 +1,97 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"sync"
+)
+
+const (
+	// DefaultRetryBudgetRatio is the default number of retries earned by each request.
+	DefaultRetryBudgetRatio = 0.1
+
+	// DefaultRetryBudgetMaxTokens is the default capacity of a RetryBudget.
+	DefaultRetryBudgetMaxTokens = 10
+)
+
+// RetryBudget is a token bucket that caps the automatic retries performed by one or
+// more BaseService instances (see ServiceOptions.RetryBudget) to a fraction of their
+// total traffic, so that retries don't multiply the load on a service that is failing.
+//
+// Each request adds "ratio" tokens to the bucket (up to "maxTokens"), and each retry
+// takes one token from it. When the bucket holds less than one token, failed requests
+// are not retried. The bucket starts out full.
+//
+// A RetryBudget is safe for concurrent use and may be shared by multiple services.
+type RetryBudget struct {
+	mutex     sync.Mutex
+	ratio     float64
+	maxTokens float64
+	tokens    float64
+}
+
+// NewRetryBudget returns a new RetryBudget that allows "ratio" retries per request
+// (e.g. 0.1 for at most one retry every ten requests), with a capacity of "maxTokens" retries.
+// If "ratio" and/or "maxTokens" are specified as 0, then default values are used instead.
+func NewRetryBudget(ratio float64, maxTokens int) *RetryBudget {
+	if ratio <= 0 {
+		ratio = DefaultRetryBudgetRatio
+	}
+	if maxTokens <= 0 {
+		maxTokens = DefaultRetryBudgetMaxTokens
+	}
+	return &RetryBudget{
+		ratio:     ratio,
+		maxTokens: float64(maxTokens),
+		tokens:    float64(maxTokens),
+	}
+}
+
+// GetRatio returns the number of retries earned by each request.
+func (budget *RetryBudget) GetRatio() float64 {
+	return budget.ratio
+}
+
+// Tokens returns the number of retries currently available.
+func (budget *RetryBudget) Tokens() float64 {
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+
+	return budget.tokens
+}
+
+// deposit adds the tokens earned by a request to the bucket.
+func (budget *RetryBudget) deposit() {
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+
+	budget.tokens += budget.ratio
+	if budget.tokens > budget.maxTokens {
+		budget.tokens = budget.maxTokens
+	}
+}
+
+// withdraw takes the token needed for a retry from the bucket,
+// and returns false if there isn't one.
+func (budget *RetryBudget) withdraw() bool {
+	budget.mutex.Lock()
+	defer budget.mutex.Unlock()
+
+	if budget.tokens < 1 {
+		return false
+	}
+	budget.tokens--
+	return true
+}
@@ -0,0 +1,117 @@
+//go:build all || fast || basesvc || retries
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryBudgetTokens(t *testing.T) {
+	budget := NewRetryBudget(0.5, 2)
+	assert.Equal(t, 0.5, budget.GetRatio())
+	assert.Equal(t, 2.0, budget.Tokens())
+
+	// The bucket starts out full and can't be over-filled.
+	budget.deposit()
+	assert.Equal(t, 2.0, budget.Tokens())
+
+	assert.True(t, budget.withdraw())
+	assert.True(t, budget.withdraw())
+	assert.False(t, budget.withdraw())
+
+	// Two requests earn one retry.
+	budget.deposit()
+	assert.False(t, budget.withdraw())
+	budget.deposit()
+	assert.True(t, budget.withdraw())
+	assert.Equal(t, 0.0, budget.Tokens())
+
+	budget = NewRetryBudget(0, 0)
+	assert.Equal(t, DefaultRetryBudgetRatio, budget.GetRatio())
+	assert.Equal(t, float64(DefaultRetryBudgetMaxTokens), budget.Tokens())
+}
+
+func TestRetryBudgetLimitsRetries(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+	service.SetRetryBudget(NewRetryBudget(0.5, 3))
+	assert.NotNil(t, service.GetRetryBudget())
+	service.EnableRetries(2, 10*time.Millisecond)
+
+	builder := NewRequestBuilder(GET)
+	_, _ = builder.ResolveRequestURL(server.URL, "/instances", nil)
+	req, _ := builder.Build()
+
+	// The first request is retried twice, without spending a token on its final attempt.
+	_, err = service.Request(req, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, 1.0, service.GetRetryBudget().Tokens())
+
+	// The second request earns half a token, so it is retried once.
+	_, err = service.Request(req, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 5, requests)
+	assert.Equal(t, 0.5, service.GetRetryBudget().Tokens())
+
+	// The third request earns another half token, so it is retried once.
+	_, err = service.Request(req, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 7, requests)
+
+	// The budget is exhausted, so the fourth request is not retried.
+	_, err = service.Request(req, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 8, requests)
+}
+
+func TestConfigureServiceRetryBudget(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           "https://myservice/api",
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+
+	t.Setenv("CATALOG_SERVICE_RETRY_BUDGET_RATIO", "notafloat")
+	assert.Nil(t, service.ConfigureService("catalog_service"))
+	assert.Nil(t, service.GetRetryBudget())
+
+	t.Setenv("CATALOG_SERVICE_RETRY_BUDGET_RATIO", "0.2")
+	t.Setenv("CATALOG_SERVICE_RETRY_BUDGET_MAX_TOKENS", "50")
+	assert.Nil(t, service.ConfigureService("catalog_service"))
+	budget := service.GetRetryBudget()
+	assert.NotNil(t, budget)
+	assert.Equal(t, 0.2, budget.GetRatio())
+	assert.Equal(t, 50.0, budget.Tokens())
+}
@@ -0,0 +1,351 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// JitterStrategy determines how the wait time between retry attempts is randomized
+// by a RetryPolicy, so that clients that failed at the same time don't retry in lockstep.
+type JitterStrategy string
+
+const (
+	// JitterNone uses an exponential backoff (min * 2^attempt, capped at the max wait)
+	// without any randomization.
+	JitterNone JitterStrategy = "none"
+
+	// JitterFull picks a random wait time between the min wait and the exponential backoff.
+	JitterFull JitterStrategy = "full"
+
+	// JitterEqual waits for half of the exponential backoff, plus a random amount
+	// of time up to the other half.
+	JitterEqual JitterStrategy = "equal"
+
+	// JitterDecorrelated picks a random wait time between the min wait and three times
+	// the wait time before the previous retry of the same request (or the min wait,
+	// for the first retry), capped at the max wait.
+	JitterDecorrelated JitterStrategy = "decorrelated"
+)
+
+// defaultRetryWaitMin is the min wait used by the retryable client if no RetryPolicy
+// specifies one (this is the go-retryablehttp default).
+const defaultRetryWaitMin = 1 * time.Second
+
+// RetryPolicy is a declarative description of the automatic retries performed by a BaseService
+// (see ServiceOptions.RetryPolicy and BaseService.SetRetryPolicy). Fields that are not set
+// retain the behavior of IBMCloudSDKRetryPolicy and IBMCloudSDKBackoffPolicy.
+//
+// A failed request is retried if its method is retryable, and either the request could
+// not be sent because of a transient error (e.g. a connection reset) or the response's status
+// code is retryable for the request's method. The "Retry-After" header of a response is always
+// honoured when computing the wait time before the next attempt.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries of a request.
+	// If not specified, go-retryablehttp's default (4) is used.
+	MaxRetries int
+
+	// MinWait and MaxWait are the minimum and maximum wait times between attempts.
+	// If not specified, 1 second and 30 seconds are used.
+	MinWait time.Duration
+	MaxWait time.Duration
+
+	// MaxElapsed is the maximum amount of time since a request was first sent after
+	// which no more retries are attempted. If not specified, there is no limit.
+	MaxElapsed time.Duration
+
+	// Jitter is the strategy used to randomize the wait time between attempts.
+	// If not specified, JitterNone is used.
+	Jitter JitterStrategy
+
+	// StatusCodes are the status codes of the responses that are retried.
+	// If not specified, 429 and the 5xx status codes other than 501 are retried.
+	StatusCodes []int
+
+	// Methods are the HTTP methods of the requests that may be retried.
+	// If not specified, requests with any method may be retried.
+	Methods []string
+
+	// MethodStatusCodes overrides StatusCodes for specific HTTP methods
+	// (e.g. to retry POST requests only on 429 and 503).
+	MethodStatusCodes map[string][]int
+
+	// ShouldRetry is an optional hook that makes the final decision whether to retry a request.
+	// It is invoked after each attempt with the request's Context, the response and/or error
+	// of the attempt, and the decision made by the policy, and returns true to retry the request.
+	// It is not invoked if the request's Context is done.
+	ShouldRetry func(ctx context.Context, resp *http.Response, err error, retry bool) bool
+}
+
+// checkRetry returns true if the request with the specified method,
+// first sent at "start", should be retried after receiving "resp" or "err".
+func (policy *RetryPolicy) checkRetry(ctx context.Context, method string, start time.Time,
+	resp *http.Response, err error) (bool, error) {
+	retry, retryErr := checkRetry(ctx, resp, err)
+	if ctx.Err() != nil {
+		return retry, retryErr
+	}
+
+	if err == nil {
+		retry = policy.isRetryableStatusCode(method, resp.StatusCode)
+	}
+
+	if retry && len(policy.Methods) > 0 &&
+		!slices.ContainsFunc(policy.Methods, func(m string) bool { return strings.EqualFold(m, method) }) {
+		GetLogger().Debug("No retry for method: %s\n", method)
+		retry = false
+	}
+
+	if retry && policy.MaxElapsed > 0 && time.Since(start) >= policy.MaxElapsed {
+		GetLogger().Debug("No retry, the maximum elapsed time (%s) has been reached\n", policy.MaxElapsed.String())
+		retry = false
+	}
+
+	if policy.ShouldRetry != nil {
+		retry = policy.ShouldRetry(ctx, resp, err, retry)
+		if retry {
+			retryErr = nil
+		}
+	}
+	return retry, retryErr
+}
+
+// isRetryableStatusCode returns true if a response with the specified status code
+// to a request with the specified method should be retried.
+func (policy *RetryPolicy) isRetryableStatusCode(method string, statusCode int) bool {
+	statusCodes := policy.StatusCodes
+	for m, codes := range policy.MethodStatusCodes {
+		if strings.EqualFold(m, method) {
+			statusCodes = codes
+			break
+		}
+	}
+
+	if statusCodes == nil {
+		return statusCode == 429 || (statusCode >= 500 && statusCode <= 599 && statusCode != 501)
+	}
+	return slices.Contains(statusCodes, statusCode)
+}
+
+// Backoff returns the wait time before the next retry attempt. It has the signature of
+// go-retryablehttp's Backoff function, and is used by the retryable client of a service
+// whose RetryPolicy is set.
+func (policy *RetryPolicy) Backoff(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	var state *requestState
+	if resp != nil && resp.Request != nil {
+		state = getRequestState(resp.Request.Context())
+	}
+	return policy.backoff(state, min, max, attemptNum, resp)
+}
+
+// backoff returns the wait time before the next retry attempt of the request described by "state"
+// (nil if unknown), and records it in "state".
+func (policy *RetryPolicy) backoff(state *requestState, min, max time.Duration, attemptNum int,
+	resp *http.Response) time.Duration {
+	wait, ok := retryAfterWait(resp, max)
+	if !ok {
+		switch policy.Jitter {
+		case JitterFull:
+			wait = randomDuration(min, exponentialBackoff(min, max, 2, attemptNum))
+		case JitterEqual:
+			backoff := exponentialBackoff(min, max, 2, attemptNum)
+			wait = randomDuration(backoff/2, backoff)
+		case JitterDecorrelated:
+			previous := min
+			if state != nil && state.retryWait > 0 {
+				previous = state.retryWait
+			}
+			wait = decorrelatedBackoff(min, max, previous)
+		default:
+			wait = exponentialBackoff(min, max, 2, attemptNum)
+		}
+	}
+	if state != nil {
+		state.retryWait = wait
+	}
+	return wait
+}
+
+// decorrelatedBackoff returns a random wait time between "min" and three times the "previous"
+// wait time, capped at "max".
+func decorrelatedBackoff(min, max, previous time.Duration) time.Duration {
+	upper := max
+	if previous < max/3 {
+		upper = previous * 3
+	}
+	wait := randomDuration(min, upper)
+	if wait > max {
+		wait = max
+	}
+	return wait
+}
+
+// retryClient returns the client used to send the request described by "state" with "client".
+// If the request's RetryPolicy uses JitterDecorrelated, then it is a copy of the retryable client
+// whose Backoff function is bound to "state", which holds the previous wait time of the request
+// (go-retryablehttp doesn't pass a response, whose request would carry "state", to the Backoff
+// function after an attempt that failed with an error).
+func (state *requestState) retryClient(client *http.Client) *http.Client {
+	policy := state.retryPolicy
+	if policy == nil || policy.Jitter != JitterDecorrelated || !isRetryableClient(client) {
+		return client
+	}
+	return cloneRetryableClient(client, func(rc *retryablehttp.Client) {
+		rc.Backoff = func(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+			return policy.backoff(state, min, max, attemptNum, resp)
+		}
+	})
+}
+
+// exponentialBackoff returns min * factor^attemptNum, capped at max.
+func exponentialBackoff(min, max time.Duration, factor float64, attemptNum int) time.Duration {
+	backoff := float64(min) * math.Pow(factor, float64(attemptNum))
+	if backoff > float64(max) || math.IsInf(backoff, 0) {
+		return max
+	}
+	return time.Duration(backoff)
+}
+
+// randomDuration returns a random duration between "min" and "max".
+func randomDuration(min, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	return min + rand.N(max-min+1) // #nosec G404
+}
+
+// retryAfterWait returns the wait time requested by the "Retry-After" header of "resp",
+// if any. A wait time specified as an HTTP date is capped at "max".
+func retryAfterWait(resp *http.Response, max time.Duration) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	s, ok := resp.Header["Retry-After"]
+	if !ok {
+		return 0, false
+	}
+	GetLogger().Debug("Found Retry-After header: %s\n", s)
+
+	// First, try to parse the value as an integer (number of seconds to wait)
+	if sleep, err := strconv.ParseInt(s[0], 10, 64); err == nil {
+		return time.Second * time.Duration(sleep), true
+	}
+
+	// Otherwise, try to parse the value as an HTTP Time value.
+	if retryTime, err := http.ParseTime(s[0]); err == nil {
+		sleep := time.Until(retryTime)
+		if sleep > max {
+			sleep = max
+		}
+		return sleep, true
+	}
+	return 0, false
+}
+
+// getRetryPolicyFromProperties returns a RetryPolicy configured with the retry policy
+// properties in "props" ("RETRY_MIN_INTERVAL" and "RETRY_MAX_ELAPSED" in seconds, "RETRY_JITTER",
+// "RETRY_STATUS_CODES" and "RETRY_METHODS"), or nil if none of them are specified.
+func getRetryPolicyFromProperties(props map[string]string) (*RetryPolicy, error) {
+	policy := &RetryPolicy{}
+	found := false
+
+	if s, ok := props[PROPNAME_SVC_RETRY_MIN_INTERVAL]; ok && s != "" {
+		found = true
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil || n < 0 {
+			err = fmt.Errorf(ERRORMSG_PROP_PARSE_ERROR, PROPNAME_SVC_RETRY_MIN_INTERVAL, s)
+			return nil, SDKErrorf(err, "", "validation-error", getComponentInfo())
+		}
+		policy.MinWait = time.Duration(n) * time.Second
+	}
+
+	if s, ok := props[PROPNAME_SVC_RETRY_MAX_ELAPSED]; ok && s != "" {
+		found = true
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil || n < 0 {
+			err = fmt.Errorf(ERRORMSG_PROP_PARSE_ERROR, PROPNAME_SVC_RETRY_MAX_ELAPSED, s)
+			return nil, SDKErrorf(err, "", "validation-error", getComponentInfo())
+		}
+		policy.MaxElapsed = time.Duration(n) * time.Second
+	}
+
+	if s, ok := props[PROPNAME_SVC_RETRY_JITTER]; ok && s != "" {
+		found = true
+		jitter := JitterStrategy(strings.ToLower(strings.TrimSpace(s)))
+		switch jitter {
+		case JitterNone, JitterFull, JitterEqual, JitterDecorrelated:
+			policy.Jitter = jitter
+		default:
+			err := fmt.Errorf(ERRORMSG_PROP_PARSE_ERROR, PROPNAME_SVC_RETRY_JITTER, s)
+			return nil, SDKErrorf(err, "", "validation-error", getComponentInfo())
+		}
+	}
+
+	if s, ok := props[PROPNAME_SVC_RETRY_STATUS_CODES]; ok && s != "" {
+		found = true
+		statusCodes, ok := parseRetryStatusCodes(s)
+		if !ok {
+			err := fmt.Errorf(ERRORMSG_PROP_PARSE_ERROR, PROPNAME_SVC_RETRY_STATUS_CODES, s)
+			return nil, SDKErrorf(err, "", "validation-error", getComponentInfo())
+		}
+		policy.StatusCodes = statusCodes
+	}
+
+	if s, ok := props[PROPNAME_SVC_RETRY_METHODS]; ok && s != "" {
+		found = true
+		policy.Methods = parseRetryMethods(s)
+	}
+
+	if !found {
+		return nil, nil
+	}
+	return policy, nil
+}
+
+// parseRetryStatusCodes parses a comma-separated list of status codes (e.g. "429,502,503"),
+// and returns false if it contains anything other than valid status codes.
+func parseRetryStatusCodes(s string) ([]int, bool) {
+	var statusCodes []int
+	for _, field := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 100 || n > 599 {
+			return nil, false
+		}
+		statusCodes = append(statusCodes, n)
+	}
+	return statusCodes, true
+}
+
+// parseRetryMethods parses a comma-separated list of HTTP methods (e.g. "GET,PUT,DELETE").
+func parseRetryMethods(s string) []string {
+	var methods []string
+	for _, field := range strings.Split(s, ",") {
+		field = strings.ToUpper(strings.TrimSpace(field))
+		if field != "" {
+			methods = append(methods, field)
+		}
+	}
+	return methods
+}
@@ -0,0 +1,295 @@
+//go:build all || fast || basesvc || retries
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	retryablehttp "github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyStatusCodes(t *testing.T) {
+	policy := &RetryPolicy{}
+	assert.True(t, policy.isRetryableStatusCode(GET, 429))
+	assert.True(t, policy.isRetryableStatusCode(GET, 503))
+	assert.False(t, policy.isRetryableStatusCode(GET, 501))
+	assert.False(t, policy.isRetryableStatusCode(GET, 409))
+
+	policy = &RetryPolicy{
+		StatusCodes: []int{409, 503},
+		MethodStatusCodes: map[string][]int{
+			"post":   {429},
+			"DELETE": {},
+		},
+	}
+	assert.True(t, policy.isRetryableStatusCode(GET, 409))
+	assert.True(t, policy.isRetryableStatusCode(GET, 503))
+	assert.False(t, policy.isRetryableStatusCode(GET, 500))
+	assert.True(t, policy.isRetryableStatusCode(POST, 429))
+	assert.False(t, policy.isRetryableStatusCode(POST, 503))
+	assert.False(t, policy.isRetryableStatusCode(DELETE, 503))
+}
+
+func TestRetryPolicyCheckRetry(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	ctx := context.Background()
+	start := time.Now()
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable}
+	connErr := errors.New("connection reset by peer")
+
+	policy := &RetryPolicy{Methods: []string{"get", PUT}}
+	retry, err := policy.checkRetry(ctx, GET, start, resp, nil)
+	assert.True(t, retry)
+	assert.Nil(t, err)
+	retry, _ = policy.checkRetry(ctx, PUT, start, nil, connErr)
+	assert.True(t, retry)
+	retry, _ = policy.checkRetry(ctx, POST, start, resp, nil)
+	assert.False(t, retry)
+
+	// No more retries after the maximum elapsed time.
+	policy = &RetryPolicy{MaxElapsed: time.Minute}
+	retry, _ = policy.checkRetry(ctx, GET, start, resp, nil)
+	assert.True(t, retry)
+	retry, _ = policy.checkRetry(ctx, GET, start.Add(-time.Minute), resp, nil)
+	assert.False(t, retry)
+
+	// The custom predicate makes the final decision.
+	var decisions []bool
+	policy = &RetryPolicy{
+		ShouldRetry: func(ctx context.Context, resp *http.Response, err error, retry bool) bool {
+			decisions = append(decisions, retry)
+			return resp != nil && resp.StatusCode == http.StatusConflict
+		},
+	}
+	retry, _ = policy.checkRetry(ctx, GET, start, resp, nil)
+	assert.False(t, retry)
+	retry, _ = policy.checkRetry(ctx, GET, start, &http.Response{StatusCode: http.StatusConflict}, nil)
+	assert.True(t, retry)
+	assert.Equal(t, []bool{true, false}, decisions)
+
+	// The predicate isn't invoked once the Context is done.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	retry, err = policy.checkRetry(cancelled, GET, start, &http.Response{StatusCode: http.StatusConflict}, nil)
+	assert.False(t, retry)
+	assert.Equal(t, context.Canceled, err)
+	assert.Len(t, decisions, 2)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	min := 100 * time.Millisecond
+	max := 10 * time.Second
+
+	policy := &RetryPolicy{}
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(min, max, 0, nil))
+	assert.Equal(t, 800*time.Millisecond, policy.Backoff(min, max, 3, nil))
+	assert.Equal(t, max, policy.Backoff(min, max, 10, nil))
+	assert.Equal(t, max, policy.Backoff(min, max, 5000, nil))
+
+	for attempt := 0; attempt < 8; attempt++ {
+		backoff := exponentialBackoff(min, max, 2, attempt)
+
+		policy.Jitter = JitterFull
+		wait := policy.Backoff(min, max, attempt, nil)
+		assert.GreaterOrEqual(t, wait, min)
+		assert.LessOrEqual(t, wait, backoff)
+
+		policy.Jitter = JitterEqual
+		wait = policy.Backoff(min, max, attempt, nil)
+		assert.GreaterOrEqual(t, wait, backoff/2)
+		assert.LessOrEqual(t, wait, backoff)
+
+		// Without the request's state, each retry is treated as the first one.
+		policy.Jitter = JitterDecorrelated
+		wait = policy.Backoff(min, max, attempt, nil)
+		assert.GreaterOrEqual(t, wait, min)
+		assert.LessOrEqual(t, wait, 3*min)
+	}
+
+	// Each decorrelated wait time depends on the previous one of the same request.
+	state := &requestState{}
+	previous := min
+	for attempt := 0; attempt < 20; attempt++ {
+		wait := policy.backoff(state, min, max, attempt, nil)
+		assert.GreaterOrEqual(t, wait, min)
+		assert.LessOrEqual(t, wait, 3*previous)
+		assert.LessOrEqual(t, wait, max)
+		assert.Equal(t, wait, state.retryWait)
+		previous = wait
+	}
+	assert.LessOrEqual(t, decorrelatedBackoff(min, max, max), max)
+	assert.Equal(t, min, decorrelatedBackoff(min, max, min/3))
+
+	// A Retry-After header takes precedence.
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "3")
+	assert.Equal(t, 3*time.Second, policy.Backoff(min, max, 0, resp))
+}
+
+func TestRetryPolicyService(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	var statusCodes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		statusCode := http.StatusConflict
+		if r.Method == http.MethodPost {
+			statusCode = http.StatusServiceUnavailable
+		}
+		statusCodes = append(statusCodes, statusCode)
+		w.WriteHeader(statusCode)
+	}))
+	defer server.Close()
+
+	policy := &RetryPolicy{
+		MaxRetries:  2,
+		MinWait:     time.Millisecond,
+		MaxWait:     5 * time.Millisecond,
+		Jitter:      JitterFull,
+		StatusCodes: []int{409},
+	}
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+		RetryPolicy:   policy,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, policy, service.GetRetryPolicy())
+
+	// The retryable client is configured from the policy.
+	assert.True(t, isRetryableClient(service.Client))
+	client := service.Client.Transport.(*retryablehttp.RoundTripper).Client
+	assert.Equal(t, 2, client.RetryMax)
+	assert.Equal(t, time.Millisecond, client.RetryWaitMin)
+	assert.Equal(t, 5*time.Millisecond, client.RetryWaitMax)
+
+	invoke := func(method string) {
+		builder := NewRequestBuilder(method)
+		_, _ = builder.ResolveRequestURL(server.URL, "/instances", nil)
+		req, _ := builder.Build()
+		_, err := service.Request(req, nil)
+		assert.NotNil(t, err)
+	}
+
+	invoke(GET)
+	assert.Equal(t, []int{409, 409, 409}, statusCodes)
+
+	statusCodes = nil
+	invoke(POST)
+	assert.Equal(t, []int{503}, statusCodes)
+
+	// Reverting to the default policy retries 503 but not 409.
+	service.SetRetryPolicy(nil)
+	assert.Nil(t, service.GetRetryPolicy())
+	assert.Equal(t, defaultRetryWaitMin, client.RetryWaitMin)
+	client.RetryWaitMin = time.Millisecond
+
+	statusCodes = nil
+	invoke(GET)
+	assert.Equal(t, []int{409}, statusCodes)
+	statusCodes = nil
+	invoke(POST)
+	assert.Equal(t, []int{503, 503, 503}, statusCodes)
+}
+
+func TestConfigureServiceRetryPolicy(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           "https://myservice/api",
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+
+	// Without any retry policy properties, retries are enabled without a policy.
+	t.Setenv("CATALOG_SERVICE_ENABLE_RETRIES", "true")
+	t.Setenv("CATALOG_SERVICE_MAX_RETRIES", "3")
+	assert.Nil(t, service.ConfigureService("catalog_service"))
+	assert.True(t, isRetryableClient(service.Client))
+	assert.Nil(t, service.GetRetryPolicy())
+
+	t.Setenv("CATALOG_SERVICE_RETRY_INTERVAL", "20")
+	t.Setenv("CATALOG_SERVICE_RETRY_MIN_INTERVAL", "2")
+	t.Setenv("CATALOG_SERVICE_RETRY_MAX_ELAPSED", "60")
+	t.Setenv("CATALOG_SERVICE_RETRY_JITTER", "Decorrelated")
+	t.Setenv("CATALOG_SERVICE_RETRY_STATUS_CODES", "429, 502,503,504")
+	t.Setenv("CATALOG_SERVICE_RETRY_METHODS", "get,put, delete")
+	assert.Nil(t, service.ConfigureService("catalog_service"))
+	policy := service.GetRetryPolicy()
+	assert.NotNil(t, policy)
+	assert.Equal(t, 3, policy.MaxRetries)
+	assert.Equal(t, 20*time.Second, policy.MaxWait)
+	assert.Equal(t, 2*time.Second, policy.MinWait)
+	assert.Equal(t, time.Minute, policy.MaxElapsed)
+	assert.Equal(t, JitterDecorrelated, policy.Jitter)
+	assert.Equal(t, []int{429, 502, 503, 504}, policy.StatusCodes)
+	assert.Equal(t, []string{GET, PUT, DELETE}, policy.Methods)
+	client := service.Client.Transport.(*retryablehttp.RoundTripper).Client
+	assert.Equal(t, 2*time.Second, client.RetryWaitMin)
+
+	// Malformed properties are rejected.
+	for name, value := range map[string]string{
+		"RETRY_STATUS_CODES": "429,5xx",
+		"RETRY_MIN_INTERVAL": "two",
+		"RETRY_MAX_ELAPSED":  "-60",
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("CATALOG_SERVICE_"+name, value)
+			err := service.ConfigureService("catalog_service")
+			assert.NotNil(t, err)
+			assert.Contains(t, err.Error(), fmt.Sprintf("error parsing configuration property %s, value=%s", name, value))
+			assert.Equal(t, "validation-error", err.(*SDKProblem).discriminator)
+		})
+	}
+	for _, value := range []string{"429,,503", "99", "600"} {
+		_, ok := parseRetryStatusCodes(value)
+		assert.False(t, ok)
+	}
+
+	t.Setenv("CATALOG_SERVICE_RETRY_STATUS_CODES", "")
+	t.Setenv("CATALOG_SERVICE_RETRY_JITTER", "random")
+	err = service.ConfigureService("catalog_service")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "error parsing configuration property RETRY_JITTER, value=random")
+}
+
+func TestRetryPolicyRetryClient(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	client := &http.Client{Transport: &retryablehttp.RoundTripper{Client: NewRetryableHTTPClient()}}
+	state := &requestState{retryPolicy: &RetryPolicy{Jitter: JitterFull}}
+	assert.Same(t, client, state.retryClient(client))
+
+	// With decorrelated jitter, the client's Backoff function tracks the request's wait times,
+	// even after an attempt that failed with an error (and therefore without a response).
+	state.retryPolicy.Jitter = JitterDecorrelated
+	retryClient := state.retryClient(client)
+	assert.NotSame(t, client, retryClient)
+	rc := retryClient.Transport.(*retryablehttp.RoundTripper).Client
+	assert.Equal(t, client.Transport.(*retryablehttp.RoundTripper).Client.RetryMax, rc.RetryMax)
+	wait := rc.Backoff(time.Millisecond, time.Second, 0, nil)
+	assert.Equal(t, wait, state.retryWait)
+	assert.LessOrEqual(t, rc.Backoff(time.Millisecond, time.Second, 1, nil), 3*wait)
+
+	// A client that doesn't retry is left alone.
+	client = &http.Client{}
+	assert.Same(t, client, state.retryClient(client))
+}
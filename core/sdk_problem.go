@@ -47,6 +47,17 @@ type SDKProblem struct {
 	// to store the instance to pass it to a downstream
 	// SDKProblem instance.
 	httpProblem *HTTPProblem
+
+	// If the problem instance originated in the core while
+	// sending a request with automatic retries enabled,
+	// this describes each attempt to send the request.
+	attempts []RetryAttempt
+
+	// If the problem instance originated in the core while
+	// sending a request, these are the ID sent with the request
+	// and the transaction ID returned by the server, if any.
+	requestID     string
+	transactionID string
 }
 
 // GetConsoleMessage returns all public fields of
@@ -86,6 +97,14 @@ func (e *SDKProblem) GetConsoleOrderedMaps() *OrderedMaps {
 	orderedMaps.Add("function", e.Function)
 	orderedMaps.Add("component", e.Component)
 
+	if e.requestID != "" {
+		orderedMaps.Add("request_id", e.requestID)
+	}
+
+	if e.transactionID != "" {
+		orderedMaps.Add("transaction_id", e.transactionID)
+	}
+
 	return orderedMaps
 }
 
@@ -101,6 +120,10 @@ func (e *SDKProblem) GetDebugOrderedMaps() *OrderedMaps {
 		orderedMaps.Add("core_problem", e.coreProblem)
 	}
 
+	if len(e.attempts) > 0 {
+		orderedMaps.Add("attempts", e.attempts)
+	}
+
 	var orderableCausedBy OrderableProblem
 	if errors.As(e.GetCausedBy(), &orderableCausedBy) {
 		orderedMaps.Add("caused_by", orderableCausedBy.GetDebugOrderedMaps().GetMaps())
@@ -136,11 +159,16 @@ func SDKErrorf(err error, summary, discriminator string, component *ProblemCompo
 
 		if isCoreProblem(sdkCausedBy) {
 			newSDKProb.coreProblem = newSparseSDKProblem(sdkCausedBy)
+			newSDKProb.requestID = sdkCausedBy.requestID
+			newSDKProb.transactionID = sdkCausedBy.transactionID
 
 			// If we stored an HTTPProblem instance in the core, we'll want to use
 			// it as the actual "caused by" problem for the new SDK problem.
 			if sdkCausedBy.httpProblem != nil {
 				newSDKProb.causedBy = sdkCausedBy.httpProblem
+			} else {
+				// Otherwise, keep the attempts (they're part of the HTTPProblem if there is one).
+				newSDKProb.attempts = sdkCausedBy.attempts
 			}
 		}
 	}
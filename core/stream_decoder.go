@@ -0,0 +1,253 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"regexp"
+)
+
+const (
+	// APPLICATION_NDJSON is the mime type of a newline-delimited JSON stream.
+	APPLICATION_NDJSON = "application/x-ndjson"
+
+	ndjsonMimePattern = "(?i)^application\\/((x-ndjson)|(jsonl)|(x-jsonlines))(\\s*;.*)?$"
+
+	ERRORMSG_STREAM_NOT_READCLOSER = "the DetailedResponse does not contain a response body stream (io.ReadCloser)"
+	ERRORMSG_STREAM_UNEXPECTED     = "unexpected JSON content in stream: expected %s but found %v"
+	ERRORMSG_STREAM_DECODE_ITEM    = "error decoding stream item %d: %s"
+)
+
+var reNDJSONMime = regexp.MustCompile(ndjsonMimePattern)
+
+// IsNDJSONMimeType returns true iff the specified mimeType value represents
+// a newline-delimited JSON stream (e.g. "application/x-ndjson").
+func IsNDJSONMimeType(mimeType string) bool {
+	return reNDJSONMime.MatchString(mimeType)
+}
+
+// StreamDecoder decodes the items contained in a JSON response body stream one at a time,
+// so that arbitrarily large result sets can be processed without reading the entire body
+// into memory. The stream may be:
+//   - a JSON array of items (e.g. `[{...}, {...}]`)
+//   - a JSON object with an array of items in one of its properties (e.g. `{"resources": [{...}, {...}]}`)
+//   - a newline-delimited JSON (NDJSON) stream of items (e.g. `{...}\n{...}\n`)
+//
+// Each item is unmarshalled into an instance of T using a ModelUnmarshaller, in which case
+// T should be a pointer to a model (e.g. *Foo), or using the encoding/json package if no
+// unmarshaller is specified.
+type StreamDecoder[T any] struct {
+	body         io.ReadCloser
+	decoder      *json.Decoder
+	unmarshaller ModelUnmarshaller
+	propertyName string
+	ndjson       bool
+
+	started bool
+	done    bool
+	count   int
+}
+
+// NewJSONArrayStreamDecoder returns a StreamDecoder that decodes the items in a JSON array.
+// If "propertyName" is "", the stream should contain the JSON array itself.  Otherwise the
+// stream should contain a JSON object, and the array is found in the named property
+// (any other properties of the object that precede it are skipped).
+func NewJSONArrayStreamDecoder[T any](body io.ReadCloser, propertyName string, unmarshaller ModelUnmarshaller) *StreamDecoder[T] {
+	return &StreamDecoder[T]{
+		body:         body,
+		decoder:      json.NewDecoder(body),
+		unmarshaller: unmarshaller,
+		propertyName: propertyName,
+	}
+}
+
+// NewNDJSONStreamDecoder returns a StreamDecoder that decodes the items in a
+// newline-delimited JSON stream.
+func NewNDJSONStreamDecoder[T any](body io.ReadCloser, unmarshaller ModelUnmarshaller) *StreamDecoder[T] {
+	return &StreamDecoder[T]{
+		body:         body,
+		decoder:      json.NewDecoder(body),
+		unmarshaller: unmarshaller,
+		ndjson:       true,
+	}
+}
+
+// NewResponseStreamDecoder returns a StreamDecoder for the response body stream contained in
+// "detailedResponse", which should be the result of invoking BaseService.Request() with a
+// result of type *io.ReadCloser. The stream is decoded as NDJSON if the response's Content-Type
+// indicates a newline-delimited JSON stream, or as a JSON array (see NewJSONArrayStreamDecoder) otherwise.
+func NewResponseStreamDecoder[T any](detailedResponse *DetailedResponse, propertyName string, unmarshaller ModelUnmarshaller) (*StreamDecoder[T], error) {
+	var body io.ReadCloser
+	if detailedResponse != nil {
+		body, _ = detailedResponse.Result.(io.ReadCloser)
+	}
+	if body == nil {
+		return nil, SDKErrorf(errors.New(ERRORMSG_STREAM_NOT_READCLOSER), "", "no-stream", getComponentInfo())
+	}
+
+	if IsNDJSONMimeType(detailedResponse.Headers.Get(CONTENT_TYPE)) {
+		return NewNDJSONStreamDecoder[T](body, unmarshaller), nil
+	}
+	return NewJSONArrayStreamDecoder[T](body, propertyName, unmarshaller), nil
+}
+
+// Next decodes and returns the next item in the stream.
+// When there are no more items in the stream, io.EOF is returned.
+func (d *StreamDecoder[T]) Next() (item T, err error) {
+	if d.done {
+		err = io.EOF
+		return
+	}
+
+	if !d.started {
+		d.started = true
+		if !d.ndjson {
+			if err = d.startArray(); err != nil {
+				d.done = true
+				return
+			}
+		}
+	}
+
+	if d.ndjson {
+		err = d.decodeItem(&item)
+		if err == io.EOF {
+			d.done = true
+		}
+		return
+	}
+
+	if !d.decoder.More() {
+		// Consume the array's closing bracket; anything after it is ignored.
+		d.done = true
+		_, _ = d.decoder.Token()
+		err = io.EOF
+		return
+	}
+
+	err = d.decodeItem(&item)
+	if err == io.EOF {
+		d.done = true
+		err = SDKErrorf(io.ErrUnexpectedEOF, "", "stream-truncated", getComponentInfo())
+	}
+	return
+}
+
+// Items returns an iterator over the remaining items in the stream.
+// If an error occurs while decoding an item, the error is yielded along with the
+// zero value of T and the iteration stops.
+// The stream is closed when the iteration completes or is stopped by the caller.
+func (d *StreamDecoder[T]) Items() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer d.Close() // #nosec G307
+		for {
+			item, err := d.Next()
+			if err == io.EOF {
+				return
+			}
+			if !yield(item, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Close closes the underlying response body stream.
+func (d *StreamDecoder[T]) Close() error {
+	d.done = true
+	return d.body.Close()
+}
+
+// startArray positions the decoder just after the opening bracket of the array of items.
+func (d *StreamDecoder[T]) startArray() error {
+	if d.propertyName != "" {
+		if err := d.expectDelim('{', "an object"); err != nil {
+			return err
+		}
+
+		for {
+			token, err := d.decoder.Token()
+			if err != nil {
+				return d.tokenError(err)
+			}
+
+			// If we reached the end of the object, then the property was not present.
+			if token == json.Delim('}') {
+				return io.EOF
+			}
+
+			if token == d.propertyName {
+				break
+			}
+
+			// Skip over the value of any other property.
+			var skipped json.RawMessage
+			if err := d.decoder.Decode(&skipped); err != nil {
+				return d.tokenError(err)
+			}
+		}
+	}
+
+	return d.expectDelim('[', "an array")
+}
+
+// expectDelim reads the next token from the stream and verifies that it is "delim".
+func (d *StreamDecoder[T]) expectDelim(delim json.Delim, description string) error {
+	token, err := d.decoder.Token()
+	if err != nil {
+		return d.tokenError(err)
+	}
+	if token == nil && d.propertyName != "" && delim == '[' {
+		// A null array contains no items.
+		return io.EOF
+	}
+	if token != delim {
+		err = fmt.Errorf(ERRORMSG_STREAM_UNEXPECTED, description, token)
+		return SDKErrorf(err, "", "unexpected-json", getComponentInfo())
+	}
+	return nil
+}
+
+func (d *StreamDecoder[T]) tokenError(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return SDKErrorf(err, "", "stream-token-error", getComponentInfo())
+}
+
+// decodeItem decodes the next JSON value in the stream into "item".
+func (d *StreamDecoder[T]) decodeItem(item *T) (err error) {
+	d.count++
+	if d.unmarshaller == nil {
+		err = d.decoder.Decode(item)
+	} else {
+		var rawMap map[string]json.RawMessage
+		err = d.decoder.Decode(&rawMap)
+		if err == nil && rawMap != nil {
+			err = d.unmarshaller(rawMap, item)
+		}
+	}
+
+	if err != nil && err != io.EOF {
+		d.done = true
+		err = fmt.Errorf(ERRORMSG_STREAM_DECODE_ITEM, d.count, err.Error())
+		err = SDKErrorf(err, "", "item-decode-error", getComponentInfo())
+	}
+	return
+}
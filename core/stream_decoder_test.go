@@ -0,0 +1,194 @@
+//go:build all || fast || basesvc
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// trackingReadCloser records whether the stream was closed.
+type trackingReadCloser struct {
+	io.Reader
+	closed bool
+}
+
+func (r *trackingReadCloser) Close() error {
+	r.closed = true
+	return nil
+}
+
+func newTrackingReadCloser(s string) *trackingReadCloser {
+	return &trackingReadCloser{Reader: strings.NewReader(s)}
+}
+
+// collectNames decodes all items of "decoder" and returns their names.
+func collectNames(t *testing.T, decoder *StreamDecoder[*Foo]) []string {
+	var names []string
+	for foo, err := range decoder.Items() {
+		assert.Nil(t, err)
+		if err != nil {
+			break
+		}
+		names = append(names, *foo.Name)
+	}
+	return names
+}
+
+func TestStreamDecoderTopLevelArray(t *testing.T) {
+	body := newTrackingReadCloser(`[{"name": "a"}, {"name": "b"}, {"name": "c"}]`)
+	decoder := NewJSONArrayStreamDecoder[*Foo](body, "", unmarshalFoo)
+	assert.Equal(t, []string{"a", "b", "c"}, collectNames(t, decoder))
+	assert.True(t, body.closed)
+}
+
+func TestStreamDecoderNestedArray(t *testing.T) {
+	body := newTrackingReadCloser(`{"limit": 3, "first": {"href": "x"}, "resources": [{"name": "a"}, {"name": "b"}], "total_count": 2}`)
+	decoder := NewJSONArrayStreamDecoder[*Foo](body, "resources", unmarshalFoo)
+	assert.Equal(t, []string{"a", "b"}, collectNames(t, decoder))
+}
+
+func TestStreamDecoderNestedArrayMissing(t *testing.T) {
+	decoder := NewJSONArrayStreamDecoder[*Foo](newTrackingReadCloser(`{"limit": 3}`), "resources", unmarshalFoo)
+	assert.Nil(t, collectNames(t, decoder))
+
+	decoder = NewJSONArrayStreamDecoder[*Foo](newTrackingReadCloser(`{"resources": null}`), "resources", unmarshalFoo)
+	assert.Nil(t, collectNames(t, decoder))
+}
+
+func TestStreamDecoderNDJSON(t *testing.T) {
+	body := newTrackingReadCloser("{\"name\": \"a\"}\n{\"name\": \"b\"}\n\n{\"name\": \"c\"}\n")
+	decoder := NewNDJSONStreamDecoder[*Foo](body, unmarshalFoo)
+	assert.Equal(t, []string{"a", "b", "c"}, collectNames(t, decoder))
+}
+
+func TestStreamDecoderPrimitives(t *testing.T) {
+	decoder := NewJSONArrayStreamDecoder[int64](newTrackingReadCloser(`[1, 2, 3]`), "", nil)
+
+	item, err := decoder.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), item)
+	item, _ = decoder.Next()
+	assert.Equal(t, int64(2), item)
+	item, _ = decoder.Next()
+	assert.Equal(t, int64(3), item)
+
+	_, err = decoder.Next()
+	assert.Equal(t, io.EOF, err)
+	_, err = decoder.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestStreamDecoderBreak(t *testing.T) {
+	body := newTrackingReadCloser(`[{"name": "a"}, {"name": "b"}, {"name": "c"}]`)
+	decoder := NewJSONArrayStreamDecoder[*Foo](body, "", unmarshalFoo)
+	for foo, err := range decoder.Items() {
+		assert.Nil(t, err)
+		assert.Equal(t, "a", *foo.Name)
+		break
+	}
+	assert.True(t, body.closed)
+}
+
+func TestStreamDecoderErrors(t *testing.T) {
+	// Not an array.
+	decoder := NewJSONArrayStreamDecoder[*Foo](newTrackingReadCloser(`{"name": "a"}`), "", unmarshalFoo)
+	_, err := decoder.Next()
+	assert.NotNil(t, err)
+	assert.Equal(t, "unexpected JSON content in stream: expected an array but found {", err.Error())
+
+	// Truncated stream.
+	decoder = NewJSONArrayStreamDecoder[*Foo](newTrackingReadCloser(`[{"name": "a"}, {"name": "b`), "", unmarshalFoo)
+	_, err = decoder.Next()
+	assert.Nil(t, err)
+	_, err = decoder.Next()
+	assert.NotNil(t, err)
+	assert.Equal(t, "error decoding stream item 2: unexpected EOF", err.Error())
+
+	// The decoder is done after an error, even if the array ends between items.
+	for _, body := range []string{`[{"name": "a"}`, `[{"name": "a"}, `} {
+		decoder = NewJSONArrayStreamDecoder[*Foo](newTrackingReadCloser(body), "", nil)
+		_, err = decoder.Next()
+		assert.Nil(t, err)
+		_, err = decoder.Next()
+		assert.NotNil(t, err)
+		_, err = decoder.Next()
+		assert.Equal(t, io.EOF, err)
+	}
+
+	// Unmarshaller error.
+	var iterErr error
+	decoder = NewJSONArrayStreamDecoder[*Foo](newTrackingReadCloser(`[{"name": "a"}, {"name": 38}]`), "", unmarshalFoo)
+	count := 0
+	for _, err := range decoder.Items() {
+		count++
+		iterErr = err
+	}
+	assert.Equal(t, 2, count)
+	assert.NotNil(t, iterErr)
+	assert.Contains(t, iterErr.Error(), "error decoding stream item 2: error unmarshalling property 'name'")
+}
+
+func TestResponseStreamDecoder(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", APPLICATION_NDJSON)
+		for i := 0; i < 100; i++ {
+			fmt.Fprintf(w, "{\"name\": \"item-%d\"}\n", i)
+		}
+	}))
+	defer server.Close()
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+
+	builder := NewRequestBuilder(GET)
+	_, err = builder.ResolveRequestURL(server.URL, "/export", nil)
+	assert.Nil(t, err)
+	req, _ := builder.Build()
+
+	var body io.ReadCloser
+	detailedResponse, err := service.Request(req, &body)
+	assert.Nil(t, err)
+
+	decoder, err := NewResponseStreamDecoder[*Foo](detailedResponse, "", unmarshalFoo)
+	assert.Nil(t, err)
+	names := collectNames(t, decoder)
+	assert.Len(t, names, 100)
+	assert.Equal(t, "item-99", names[99])
+
+	_, err = NewResponseStreamDecoder[*Foo](&DetailedResponse{Result: map[string]interface{}{}}, "", unmarshalFoo)
+	assert.NotNil(t, err)
+	assert.Equal(t, ERRORMSG_STREAM_NOT_READCLOSER, err.Error())
+}
+
+func TestIsNDJSONMimeType(t *testing.T) {
+	assert.True(t, IsNDJSONMimeType("application/x-ndjson"))
+	assert.True(t, IsNDJSONMimeType("application/x-ndjson; charset=utf-8"))
+	assert.True(t, IsNDJSONMimeType("application/jsonl"))
+	assert.False(t, IsNDJSONMimeType("application/json"))
+	assert.False(t, IsNDJSONMimeType(""))
+}
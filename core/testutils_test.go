@@ -0,0 +1,75 @@
+//go:build all || fast || basesvc || retries
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testServer is a test server started by startTestServer, which counts the requests it receives.
+type testServer struct {
+	*httptest.Server
+	requests atomic.Int32
+}
+
+// startTestServer starts a test server that responds to each request with the specified
+// status code, content type (if not empty) and body.
+func startTestServer(statusCode int, contentType string, body string) *testServer {
+	server := &testServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.requests.Add(1)
+		if contentType != "" {
+			w.Header().Set(CONTENT_TYPE, contentType)
+		}
+		w.WriteHeader(statusCode)
+		fmt.Fprint(w, body)
+	}))
+	return server
+}
+
+// newTestService returns a new BaseService configured with "options"
+// (and a NoAuthAuthenticator, if the options don't specify an authenticator).
+func newTestService(t *testing.T, options *ServiceOptions) *BaseService {
+	if options.Authenticator == nil {
+		options.Authenticator = &NoAuthAuthenticator{}
+	}
+	service, err := NewBaseService(options)
+	assert.Nil(t, err)
+	return service
+}
+
+// buildTestRequest builds a request for "path" (relative to the service's URL) with "builder".
+func buildTestRequest(t *testing.T, service *BaseService, builder *RequestBuilder, path string) *http.Request {
+	_, err := builder.ResolveRequestURL(service.GetServiceURL(), path, nil)
+	assert.Nil(t, err)
+	req, err := builder.Build()
+	assert.Nil(t, err)
+	return req
+}
+
+// invokeTestRequest sends a request for "path" (relative to the service's URL) built with "builder",
+// and unmarshals the response body into "result" (unless nil).
+func invokeTestRequest(t *testing.T, service *BaseService, builder *RequestBuilder, path string,
+	result interface{}) (*DetailedResponse, error) {
+	return service.Request(buildTestRequest(t, service, builder, path), result)
+}
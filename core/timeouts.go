@@ -0,0 +1,160 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	ERRORMSG_REQUEST_TIMEOUT    = "The request did not complete within the request timeout (%s)"
+	ERRORMSG_ATTEMPT_TIMEOUT    = "The request attempt did not complete within the attempt timeout (%s)"
+	ERRORMSG_FIRST_BYTE_TIMEOUT = "No response was received within the time-to-first-byte timeout (%s)"
+)
+
+// RequestTimeouts describes the timeouts applied to the requests sent by a service
+// (see ServiceOptions.Timeouts) or to a single request (see RequestBuilder.Timeouts and
+// WithRequestTimeouts), in addition to the timeout of the service's http.Client.
+// A timeout that is not positive is not applied.
+//
+// A request that exceeds one of its timeouts fails with an SDKProblem whose discriminator
+// identifies the timeout: "request-timeout", "attempt-timeout" or "first-byte-timeout".
+type RequestTimeouts struct {
+	// Total is the maximum amount of time taken to send the request and receive its response
+	// (including the response body), across all the attempts made if automatic retries are enabled.
+	Total time.Duration
+
+	// Attempt is the maximum amount of time taken by each attempt to send the request and receive
+	// its response (including the response body). An attempt that times out may be retried.
+	Attempt time.Duration
+
+	// FirstByte is the maximum amount of time waited for the response headers after each attempt
+	// to send the request is started. An attempt that times out may be retried.
+	FirstByte time.Duration
+}
+
+// merge returns the timeouts in "timeouts", with those that are not set taken from "defaults".
+func (timeouts RequestTimeouts) merge(defaults RequestTimeouts) RequestTimeouts {
+	if timeouts.Total <= 0 {
+		timeouts.Total = defaults.Total
+	}
+	if timeouts.Attempt <= 0 {
+		timeouts.Attempt = defaults.Attempt
+	}
+	if timeouts.FirstByte <= 0 {
+		timeouts.FirstByte = defaults.FirstByte
+	}
+	return timeouts
+}
+
+type requestTimeoutsKey struct{}
+
+// WithRequestTimeouts returns a copy of "ctx" that carries "timeouts", so that the requests
+// associated with the returned Context are sent with these timeouts. Timeouts that are not set
+// are taken from the service's timeouts (see ServiceOptions.Timeouts).
+func WithRequestTimeouts(ctx context.Context, timeouts RequestTimeouts) context.Context {
+	return context.WithValue(ctx, requestTimeoutsKey{}, timeouts)
+}
+
+// requestTimeouts returns the timeouts to be applied to "req".
+func (service *BaseService) requestTimeouts(req *http.Request) RequestTimeouts {
+	timeouts, _ := req.Context().Value(requestTimeoutsKey{}).(RequestTimeouts)
+	return timeouts.merge(service.Options.Timeouts)
+}
+
+// timeoutError is the error that cancels the Context of a request that exceeds one of its timeouts.
+type timeoutError struct {
+	discriminator string
+	message       string
+	timeout       time.Duration
+}
+
+func (e *timeoutError) Error() string {
+	return fmt.Sprintf(e.message, e.timeout.String())
+}
+
+// withTotalTimeout returns a copy of "req" whose Context is cancelled after "timeout", and a function
+// that releases the Context.
+func withTotalTimeout(req *http.Request, timeout time.Duration) (*http.Request, context.CancelFunc) {
+	err := &timeoutError{discriminator: "request-timeout", message: ERRORMSG_REQUEST_TIMEOUT, timeout: timeout}
+	ctx, cancel := context.WithTimeoutCause(req.Context(), timeout, err)
+	return req.WithContext(ctx), cancel
+}
+
+// clientWithAttemptTimeouts returns a copy of "client" that applies the attempt and time-to-first-byte
+// timeouts in "timeouts" to each attempt to send a request.
+func clientWithAttemptTimeouts(client *http.Client, timeouts RequestTimeouts) *http.Client {
+	return clientWithTransport(client, func(transport http.RoundTripper) http.RoundTripper {
+		return &timeoutTransport{base: transport, attempt: timeouts.Attempt, firstByte: timeouts.FirstByte}
+	})
+}
+
+// attemptError returns the error of an attempt, made with Context "ctx", that failed with "err":
+// if the attempt was cancelled by one of the request's timeouts, then the error is the timeout.
+func attemptError(ctx context.Context, err error) error {
+	var timeoutErr *timeoutError
+	if err != nil && errors.As(context.Cause(ctx), &timeoutErr) {
+		return timeoutErr
+	}
+	return err
+}
+
+// timeoutTransport is an http.RoundTripper that applies an attempt timeout and a
+// time-to-first-byte timeout to each request that it sends.
+type timeoutTransport struct {
+	base      http.RoundTripper
+	attempt   time.Duration
+	firstByte time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancelCause(req.Context())
+	startTimer := func(discriminator, message string, timeout time.Duration) *time.Timer {
+		if timeout <= 0 {
+			return nil
+		}
+		err := &timeoutError{discriminator: discriminator, message: message, timeout: timeout}
+		return time.AfterFunc(timeout, func() { cancel(err) })
+	}
+	stopTimer := func(timer *time.Timer) {
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+	attemptTimer := startTimer("attempt-timeout", ERRORMSG_ATTEMPT_TIMEOUT, t.attempt)
+	firstByteTimer := startTimer("first-byte-timeout", ERRORMSG_FIRST_BYTE_TIMEOUT, t.firstByte)
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	stopTimer(firstByteTimer)
+	if err != nil {
+		stopTimer(attemptTimer)
+		if cause, ok := context.Cause(ctx).(*timeoutError); ok {
+			err = cause
+		}
+		cancel(nil)
+		return nil, err
+	}
+
+	// The attempt timeout also applies to the response body, which can be read until it is closed.
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: func() {
+		stopTimer(attemptTimer)
+		cancel(nil)
+	}}
+	return resp, nil
+}
@@ -0,0 +1,158 @@
+//go:build all || fast || basesvc || retries
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTimeoutsTestServer returns a server that waits for "headerDelay" before sending the
+// response headers of the first "slow" requests, and then waits for "bodyDelay" before
+// sending the response body.
+func newTimeoutsTestServer(slow int32, headerDelay, bodyDelay time.Duration) *httptest.Server {
+	var requests atomic.Int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= slow {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(headerDelay):
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		time.Sleep(bodyDelay)
+		_, _ = w.Write([]byte(`{"name": "wonder woman"}`))
+	}))
+}
+
+// newTimeoutsRequestBuilder returns a builder for a GET request with the specified timeouts.
+func newTimeoutsRequestBuilder(timeouts RequestTimeouts) *RequestBuilder {
+	builder := NewRequestBuilder(GET)
+	builder.Timeouts = timeouts
+	return builder
+}
+
+func TestRequestTimeoutsMerge(t *testing.T) {
+	defaults := RequestTimeouts{Total: time.Minute, Attempt: 10 * time.Second, FirstByte: time.Second}
+	timeouts := RequestTimeouts{Attempt: 5 * time.Second}.merge(defaults)
+	assert.Equal(t, RequestTimeouts{Total: time.Minute, Attempt: 5 * time.Second, FirstByte: time.Second}, timeouts)
+
+	service := newTestService(t, &ServiceOptions{URL: "https://myservice/api"})
+	service.SetTimeouts(defaults)
+	assert.Equal(t, defaults, service.GetTimeouts())
+
+	req, _ := http.NewRequest(GET, "https://myservice/api", nil)
+	assert.Equal(t, defaults, service.requestTimeouts(req))
+	req = req.WithContext(WithRequestTimeouts(context.Background(), RequestTimeouts{Total: time.Hour}))
+	assert.Equal(t, time.Hour, service.requestTimeouts(req).Total)
+	assert.Equal(t, time.Second, service.requestTimeouts(req).FirstByte)
+}
+
+func TestRequestTimeoutTotal(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := newTimeoutsTestServer(1, 500*time.Millisecond, 0)
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	service.EnableRetries(2, 10*time.Millisecond)
+	service.SetTimeouts(RequestTimeouts{Total: 50 * time.Millisecond})
+
+	start := time.Now()
+	_, err := invokeTestRequest(t, service, NewRequestBuilder(GET), "/instances", new(map[string]interface{}))
+	assert.NotNil(t, err)
+	assert.Less(t, time.Since(start), 400*time.Millisecond)
+	sdkProblem, ok := err.(*SDKProblem)
+	assert.True(t, ok)
+	assert.Equal(t, "request-timeout", sdkProblem.discriminator)
+	assert.Contains(t, err.Error(), "The request did not complete within the request timeout (50ms)")
+
+	// The request's timeout overrides the service's timeout, and remains
+	// in effect while the response body is read.
+	server2 := newTimeoutsTestServer(0, 0, 50*time.Millisecond)
+	defer server2.Close()
+	_ = service.SetServiceURL(server2.URL)
+	builder := newTimeoutsRequestBuilder(RequestTimeouts{Total: 5 * time.Second})
+	detailedResponse, err := invokeTestRequest(t, service, builder, "/instances", new(map[string]interface{}))
+	assert.Nil(t, err)
+	assert.Equal(t, "wonder woman", detailedResponse.Result.(map[string]interface{})["name"])
+
+	builder = newTimeoutsRequestBuilder(RequestTimeouts{Total: 20 * time.Millisecond})
+	_, err = invokeTestRequest(t, service, builder, "/instances", new(map[string]interface{}))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "The request did not complete within the request timeout (20ms)")
+}
+
+func TestRequestTimeoutAttempt(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := newTimeoutsTestServer(1, 500*time.Millisecond, 0)
+	defer server.Close()
+
+	// Without retries, the request fails.
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	builder := newTimeoutsRequestBuilder(RequestTimeouts{Attempt: 50 * time.Millisecond})
+	_, err := invokeTestRequest(t, service, builder, "/instances", new(map[string]interface{}))
+	assert.NotNil(t, err)
+	sdkProblem, ok := err.(*SDKProblem)
+	assert.True(t, ok)
+	assert.Equal(t, "attempt-timeout", sdkProblem.discriminator)
+	assert.Contains(t, err.Error(), "The request attempt did not complete within the attempt timeout (50ms)")
+
+	// With retries, the attempt that timed out is retried.
+	server2 := newTimeoutsTestServer(1, 500*time.Millisecond, 0)
+	defer server2.Close()
+	service = newTestService(t, &ServiceOptions{URL: server2.URL})
+	service.EnableRetries(2, 10*time.Millisecond)
+	builder = newTimeoutsRequestBuilder(RequestTimeouts{Attempt: 50 * time.Millisecond})
+	detailedResponse, err := invokeTestRequest(t, service, builder, "/instances", new(map[string]interface{}))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, detailedResponse.StatusCode)
+	assert.Len(t, detailedResponse.Attempts, 2)
+	assert.Contains(t, detailedResponse.Attempts[0].Error, "attempt timeout")
+	assert.Equal(t, http.StatusOK, detailedResponse.Attempts[1].StatusCode)
+}
+
+func TestRequestTimeoutFirstByte(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := newTimeoutsTestServer(1, 500*time.Millisecond, 0)
+	defer server.Close()
+
+	service := newTestService(t, &ServiceOptions{URL: server.URL})
+	service.SetTimeouts(RequestTimeouts{FirstByte: 50 * time.Millisecond})
+	_, err := invokeTestRequest(t, service, NewRequestBuilder(GET), "/instances", new(map[string]interface{}))
+	assert.NotNil(t, err)
+	sdkProblem, ok := err.(*SDKProblem)
+	assert.True(t, ok)
+	assert.Equal(t, "first-byte-timeout", sdkProblem.discriminator)
+	assert.Contains(t, err.Error(), "No response was received within the time-to-first-byte timeout (50ms)")
+
+	// The time-to-first-byte timeout doesn't apply to the response body.
+	server2 := newTimeoutsTestServer(0, 0, 100*time.Millisecond)
+	defer server2.Close()
+	_ = service.SetServiceURL(server2.URL)
+	detailedResponse, err := invokeTestRequest(t, service, NewRequestBuilder(GET), "/instances", new(map[string]interface{}))
+	assert.Nil(t, err)
+	assert.Equal(t, "wonder woman", detailedResponse.Result.(map[string]interface{})["name"])
+}
@@ -0,0 +1,149 @@
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"net/http"
+)
+
+// The names of the spans started by the Go core.
+const (
+	// SPAN_NAME_REQUEST is the name of the span that describes a request
+	// processed by a BaseService (including all of its retry attempts).
+	SPAN_NAME_REQUEST = "ibm.sdk.request"
+
+	// SPAN_NAME_REQUEST_ATTEMPT is the name of the span that describes each attempt
+	// to send a request, if automatic retries are enabled.
+	SPAN_NAME_REQUEST_ATTEMPT = "ibm.sdk.request.attempt"
+
+	// SPAN_NAME_TOKEN_REQUEST is the name of the span that describes
+	// a token request made by an authenticator. It is always a root span: a token is cached
+	// and shared by the requests of all the services that use the authenticator (and may be
+	// refreshed in the background), so it isn't attributed to the request that triggered it.
+	SPAN_NAME_TOKEN_REQUEST = "ibm.sdk.auth.request_token"
+)
+
+// The attributes set on the spans started by the Go core.
+// Where possible, they follow the OpenTelemetry semantic conventions.
+const (
+	SPAN_ATTR_HTTP_METHOD      = "http.request.method"
+	SPAN_ATTR_HTTP_STATUS_CODE = "http.response.status_code"
+	SPAN_ATTR_HTTP_RESEND      = "http.request.resend_count"
+	SPAN_ATTR_URL              = "url.full"
+	SPAN_ATTR_SERVER_ADDRESS   = "server.address"
+	SPAN_ATTR_REQUEST_ID       = "ibm.request_id"
+	SPAN_ATTR_TRANSACTION_ID   = "ibm.transaction_id"
+	SPAN_ATTR_AUTH_TYPE        = "ibm.auth.type"
+)
+
+const (
+	// headerNameTraceParent is the name of the W3C Trace Context header that identifies
+	// the span of a request (see ServiceOptions.EnableTraceParent).
+	headerNameTraceParent = "traceparent"
+)
+
+// Tracer is the interface used by the Go core to trace the requests processed by services
+// and the token requests made by authenticators. It has no dependencies so that it can be
+// implemented by a thin adapter for a tracing library, such as OpenTelemetry.
+// The Tracer used by the Go core is set with SetTracer.
+type Tracer interface {
+	// StartSpan starts a new span named "name" as a child of the span carried by "ctx",
+	// if any, and returns the span along with a copy of "ctx" that carries it.
+	StartSpan(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span describes an operation traced by a Tracer.
+type Span interface {
+	// SetAttribute sets an attribute of the span.
+	SetAttribute(key string, value interface{})
+
+	// RecordError records that the operation described by the span failed with "err".
+	RecordError(err error)
+
+	// End ends the span.
+	End()
+
+	// TraceParent returns the value of the W3C Trace Context "traceparent" header
+	// that identifies the span, or "" if it isn't known.
+	TraceParent() string
+}
+
+// noopTracer is the default Tracer, which doesn't trace anything.
+type noopTracer struct{}
+
+func (noopTracer) StartSpan(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, interface{}) {}
+func (noopSpan) RecordError(error)                {}
+func (noopSpan) End()                             {}
+func (noopSpan) TraceParent() string              { return "" }
+
+// sdkTracer holds the Tracer implementation used by the Go core library.
+var sdkTracer Tracer = noopTracer{}
+
+// SetTracer sets the specified Tracer instance as the tracer to be used by the Go core library
+// (nil to disable tracing).
+func SetTracer(tracer Tracer) {
+	if tracer == nil {
+		tracer = noopTracer{}
+	}
+	sdkTracer = tracer
+}
+
+// GetTracer returns the Tracer instance currently used by the Go core.
+func GetTracer() Tracer {
+	return sdkTracer
+}
+
+// startRequestSpan starts a span named "name" that describes "req" (or one of its attempts),
+// and returns a copy of "req" whose Context carries the span.
+func startRequestSpan(req *http.Request, name string) (*http.Request, Span) {
+	ctx, span := GetTracer().StartSpan(req.Context(), name)
+	span.SetAttribute(SPAN_ATTR_HTTP_METHOD, req.Method)
+	span.SetAttribute(SPAN_ATTR_URL, req.URL.Redacted())
+	span.SetAttribute(SPAN_ATTR_SERVER_ADDRESS, req.URL.Hostname())
+	return req.WithContext(ctx), span
+}
+
+// injectTraceParent sets the "traceparent" header of "req" to identify "span".
+func injectTraceParent(req *http.Request, span Span) {
+	if traceParent := span.TraceParent(); traceParent != "" {
+		req.Header.Set(headerNameTraceParent, traceParent)
+	}
+}
+
+// endSpan records "err" (if any) and ends "span".
+func endSpan(span Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// traceTokenRequest invokes "requestToken" within a span that describes
+// a token request made by an authenticator of type "authType".
+// The span is deliberately detached from the request that needed the token (see SPAN_NAME_TOKEN_REQUEST).
+func traceTokenRequest[T any](authType string, requestToken func() (T, error)) (T, error) {
+	_, span := GetTracer().StartSpan(context.Background(), SPAN_NAME_TOKEN_REQUEST)
+	span.SetAttribute(SPAN_ATTR_AUTH_TYPE, authType)
+	tokenResponse, err := requestToken()
+	endSpan(span, err)
+	return tokenResponse, err
+}
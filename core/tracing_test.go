@@ -0,0 +1,259 @@
+//go:build all || fast || basesvc || retries
+
+package core
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testTracer is a Tracer that records the spans it starts.
+type testTracer struct {
+	mutex sync.Mutex
+	spans []*testSpan
+}
+
+type testSpan struct {
+	tracer     *testTracer
+	id         int
+	name       string
+	parent     *testSpan
+	attributes map[string]interface{}
+	errors     []error
+	ended      bool
+}
+
+type testSpanKey struct{}
+
+func (tracer *testTracer) StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+	parent, _ := ctx.Value(testSpanKey{}).(*testSpan)
+	span := &testSpan{tracer: tracer, id: len(tracer.spans) + 1, name: name, parent: parent,
+		attributes: make(map[string]interface{})}
+	tracer.spans = append(tracer.spans, span)
+	return context.WithValue(ctx, testSpanKey{}, span), span
+}
+
+func (tracer *testTracer) getSpans(name string) []*testSpan {
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+	var spans []*testSpan
+	for _, span := range tracer.spans {
+		if span.name == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+func (span *testSpan) SetAttribute(key string, value interface{}) {
+	span.tracer.mutex.Lock()
+	defer span.tracer.mutex.Unlock()
+	span.attributes[key] = value
+}
+
+func (span *testSpan) RecordError(err error) {
+	span.tracer.mutex.Lock()
+	defer span.tracer.mutex.Unlock()
+	span.errors = append(span.errors, err)
+}
+
+func (span *testSpan) End() {
+	span.tracer.mutex.Lock()
+	defer span.tracer.mutex.Unlock()
+	span.ended = true
+}
+
+func (span *testSpan) TraceParent() string {
+	return fmt.Sprintf("00-0af7651916cd43dd8448eb211c80319c-%016x-01", span.id)
+}
+
+func TestTracerDefault(t *testing.T) {
+	assert.Equal(t, noopTracer{}, GetTracer())
+	tracer := &testTracer{}
+	SetTracer(tracer)
+	assert.Equal(t, tracer, GetTracer())
+	SetTracer(nil)
+	assert.Equal(t, noopTracer{}, GetTracer())
+
+	ctx, span := GetTracer().StartSpan(context.Background(), "test")
+	assert.Equal(t, context.Background(), ctx)
+	assert.Empty(t, span.TraceParent())
+}
+
+func TestTracerRequest(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	var traceParents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParents = append(traceParents, r.Header.Get("traceparent"))
+		w.Header().Set(TRANSACTION_ID_HEADER, "txn-123")
+		if len(traceParents) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	tracer := &testTracer{}
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+	service.EnableRetries(1, 10*time.Millisecond)
+	service.SetEnableTraceParent(true)
+	assert.True(t, service.GetEnableTraceParent())
+
+	builder := NewRequestBuilder(GET).WithContext(WithRequestID(context.Background(), "abc"))
+	_, _ = builder.ResolveRequestURL(server.URL, "/instances", nil)
+	req, _ := builder.Build()
+	_, err = service.Request(req, nil)
+	assert.Nil(t, err)
+
+	// Each attempt is a child of the request's span, and carries its own "traceparent" header.
+	spans := tracer.getSpans(SPAN_NAME_REQUEST)
+	assert.Len(t, spans, 1)
+	span := spans[0]
+	assert.True(t, span.ended)
+	assert.Empty(t, span.errors)
+	assert.Equal(t, GET, span.attributes[SPAN_ATTR_HTTP_METHOD])
+	assert.Equal(t, server.URL+"/instances", span.attributes[SPAN_ATTR_URL])
+	assert.Equal(t, http.StatusOK, span.attributes[SPAN_ATTR_HTTP_STATUS_CODE])
+	assert.Equal(t, 1, span.attributes[SPAN_ATTR_HTTP_RESEND])
+	assert.Equal(t, "abc", span.attributes[SPAN_ATTR_REQUEST_ID])
+	assert.Equal(t, "txn-123", span.attributes[SPAN_ATTR_TRANSACTION_ID])
+
+	attempts := tracer.getSpans(SPAN_NAME_REQUEST_ATTEMPT)
+	assert.Len(t, attempts, 2)
+	for i, attempt := range attempts {
+		assert.Equal(t, span, attempt.parent)
+		assert.True(t, attempt.ended)
+		assert.Equal(t, i, attempt.attributes[SPAN_ATTR_HTTP_RESEND])
+		assert.Equal(t, attempt.TraceParent(), traceParents[i])
+	}
+	assert.Equal(t, http.StatusServiceUnavailable, attempts[0].attributes[SPAN_ATTR_HTTP_STATUS_CODE])
+	assert.Equal(t, http.StatusOK, attempts[1].attributes[SPAN_ATTR_HTTP_STATUS_CODE])
+
+	// Without retries, the request's span is identified by the "traceparent" header.
+	service.DisableRetries()
+	traceParents = []string{"first"}
+	_, err = service.Request(req, nil)
+	assert.Nil(t, err)
+	spans = tracer.getSpans(SPAN_NAME_REQUEST)
+	assert.Len(t, spans, 2)
+	assert.Equal(t, spans[1].TraceParent(), traceParents[1])
+
+	// The "traceparent" header is optional.
+	service.SetEnableTraceParent(false)
+	req.Header.Del("traceparent")
+	_, err = service.Request(req, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, traceParents[2])
+}
+
+func TestTracerRequestError(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	tracer := &testTracer{}
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	service, err := NewBaseService(&ServiceOptions{
+		URL:           server.URL,
+		Authenticator: &NoAuthAuthenticator{},
+	})
+	assert.Nil(t, err)
+	service.EnableRetries(1, 10*time.Millisecond)
+
+	builder := NewRequestBuilder(GET)
+	_, _ = builder.ResolveRequestURL(server.URL, "/instances", nil)
+	req, _ := builder.Build()
+	_, err = service.Request(req, nil)
+	assert.NotNil(t, err)
+
+	spans := tracer.getSpans(SPAN_NAME_REQUEST)
+	assert.Len(t, spans, 1)
+	assert.Equal(t, []error{err}, spans[0].errors)
+	assert.Nil(t, spans[0].attributes[SPAN_ATTR_HTTP_STATUS_CODE])
+
+	attempts := tracer.getSpans(SPAN_NAME_REQUEST_ATTEMPT)
+	assert.Len(t, attempts, 2)
+	for _, attempt := range attempts {
+		assert.Len(t, attempt.errors, 1)
+		assert.Contains(t, attempt.errors[0].Error(), "connection refused")
+	}
+}
+
+func TestTracerTokenRequest(t *testing.T) {
+	GetLogger().SetLogLevel(basesvcAuthTestLogLevel)
+	fail := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"access_token": "token", "token_type": "Bearer", "expires_in": 3600, "expiration": %d}`,
+			GetCurrentTime()+3600)
+	}))
+	defer server.Close()
+
+	tracer := &testTracer{}
+	SetTracer(tracer)
+	defer SetTracer(nil)
+
+	authenticator, err := NewIamAuthenticatorBuilder().SetApiKey("apikey").SetURL(server.URL).Build()
+	assert.Nil(t, err)
+	_, err = authenticator.RequestToken()
+	assert.Nil(t, err)
+
+	fail = true
+	_, err = authenticator.RequestToken()
+	assert.NotNil(t, err)
+
+	spans := tracer.getSpans(SPAN_NAME_TOKEN_REQUEST)
+	assert.Len(t, spans, 2)
+	for _, span := range spans {
+		assert.True(t, span.ended)
+		assert.Equal(t, AUTHTYPE_IAM, span.attributes[SPAN_ATTR_AUTH_TYPE])
+	}
+	assert.Empty(t, spans[0].errors)
+	assert.Equal(t, []error{err}, spans[1].errors)
+
+	// The span isn't a child of the span of the request that needed the token.
+	fail = false
+	ctx, _ := tracer.StartSpan(context.Background(), SPAN_NAME_REQUEST)
+	req, _ := http.NewRequestWithContext(ctx, GET, server.URL, nil)
+	assert.Nil(t, authenticator.Authenticate(req))
+	spans = tracer.getSpans(SPAN_NAME_TOKEN_REQUEST)
+	assert.Len(t, spans, 3)
+	assert.Nil(t, spans[2].parent)
+}
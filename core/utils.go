@@ -15,6 +15,7 @@ package core
 // limitations under the License.
 
 import (
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -268,6 +269,15 @@ func ConvertSlice(slice interface{}) (s []string, err error) {
 	return nil, SDKErrorf(err, "", "cant-convert-slice", getComponentInfo())
 }
 
+// newUUID returns a new random (version 4) UUID string.
+func newUUID() string {
+	var uuid [16]byte
+	_, _ = rand.Read(uuid[:])
+	uuid[6] = (uuid[6] & 0x0f) | 0x40 // version 4
+	uuid[8] = (uuid[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
+}
+
 // SliceContains returns true iff "contains" is an element of "slice"
 func SliceContains(slice []string, contains string) bool {
 	for _, elem := range slice {
@@ -421,3 +431,8 @@ func RedactSecrets(input string) string {
 
 	return redactedString
 }
+
+// redactHeader returns the value of the "name" header with secrets redacted.
+func redactHeader(name string, value string) string {
+	return strings.TrimPrefix(RedactSecrets(name+": "+value), name+": ")
+}
@@ -302,6 +302,7 @@ func (authenticator *VpcInstanceAuthenticator) Validate() error {
 func (authenticator *VpcInstanceAuthenticator) GetToken() (string, error) {
 	if authenticator.getTokenData() == nil || !authenticator.getTokenData().isTokenValid() {
 		GetLogger().Debug("Performing synchronous token fetch...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_VPC, false)
 		// synchronously request the token
 		err := authenticator.synchronizedRequestToken()
 		if err != nil {
@@ -309,11 +310,13 @@ func (authenticator *VpcInstanceAuthenticator) GetToken() (string, error) {
 		}
 	} else if authenticator.getTokenData().needsRefresh() {
 		GetLogger().Debug("Performing background asynchronous token fetch...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_VPC, true)
 		// If refresh needed, kick off a go routine in the background to get a new token
 		//nolint: errcheck
 		go authenticator.invokeRequestTokenData()
 	} else {
 		GetLogger().Debug("Using cached access token...")
+		GetMetrics().ObserveTokenCache(AUTHTYPE_VPC, true)
 	}
 
 	// return an error if the access token is not valid or was not fetched
@@ -363,7 +366,12 @@ func (authenticator *VpcInstanceAuthenticator) invokeRequestTokenData() error {
 
 // RequestToken will use the VPC Instance Metadata Service to (1) retrieve a fresh instance identity token
 // and then (2) exchange that for an IAM access token.
-func (authenticator *VpcInstanceAuthenticator) RequestToken() (iamTokenResponse *IamTokenServerResponse, err error) {
+func (authenticator *VpcInstanceAuthenticator) RequestToken() (*IamTokenServerResponse, error) {
+	return instrumentTokenRequest(AUTHTYPE_VPC, authenticator.requestToken)
+}
+
+// requestToken is the implementation of RequestToken.
+func (authenticator *VpcInstanceAuthenticator) requestToken() (iamTokenResponse *IamTokenServerResponse, err error) {
 	// Retrieve the instance identity token from the VPC Instance Metadata Service.
 	instanceIdentityToken, err := authenticator.retrieveInstanceIdentityToken()
 	if err != nil {
@@ -452,7 +460,7 @@ func (authenticator *VpcInstanceAuthenticator) retrieveIamAccessToken(
 	}
 
 	GetLogger().Debug("Invoking VPC 'create_iam_token' operation: %s", builder.URL)
-	resp, err := authenticator.client().Do(req)
+	resp, err := recordingClient(authenticator.client(), fmt.Sprintf(harTokenRequestComment, AUTHTYPE_VPC)).Do(req)
 	if err != nil {
 		return nil, authenticationErrorf(err, &DetailedResponse{}, "noop", getComponentInfo())
 	}
@@ -544,7 +552,7 @@ func (authenticator *VpcInstanceAuthenticator) retrieveInstanceIdentityToken() (
 
 	// Invoke the request.
 	GetLogger().Debug("Invoking VPC 'create_access_token' operation: %s", builder.URL)
-	resp, err := authenticator.client().Do(req)
+	resp, err := recordingClient(authenticator.client(), fmt.Sprintf(harTokenRequestComment, AUTHTYPE_VPC)).Do(req)
 	if err != nil {
 		err = authenticationErrorf(err, &DetailedResponse{}, "noop", getComponentInfo())
 		return
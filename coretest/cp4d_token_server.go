@@ -0,0 +1,102 @@
+package coretest
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const cp4dTokenServerPath = "/v1/authorize"
+
+// Cp4dTokenServer is a fake Cloud Pak for Data token server, which implements the
+// POST /v1/authorize operation for a username and either a password or an apikey.
+type Cp4dTokenServer struct {
+	*TokenServer
+
+	password string
+}
+
+// NewCp4dTokenServer returns a new, started Cp4dTokenServer, whose URL is suitable
+// for the URL property of a CloudPakForDataAuthenticator.
+func NewCp4dTokenServer() *Cp4dTokenServer {
+	server := &Cp4dTokenServer{}
+	server.TokenServer = newTokenServer(server.handle, writeCp4dError)
+	return server
+}
+
+// SetPassword sets the only password that the server accepts. By default, any password is accepted.
+func (server *Cp4dTokenServer) SetPassword(password string) {
+	server.TokenServer.mutex.Lock()
+	defer server.TokenServer.mutex.Unlock()
+	server.password = password
+}
+
+// writeCp4dError writes an error response in the format of the Cloud Pak for Data token server.
+func writeCp4dError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"_messageCode_": fmt.Sprintf("%d", statusCode),
+		"message":       message,
+	})
+}
+
+func (server *Cp4dTokenServer) handle(w http.ResponseWriter, req *http.Request, body []byte) {
+	if !strings.HasSuffix(req.URL.Path, cp4dTokenServerPath) || req.Method != http.MethodPost {
+		writeCp4dError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	var requestBody struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		APIKey   string `json:"api_key"`
+	}
+	if json.Unmarshal(body, &requestBody) != nil {
+		writeCp4dError(w, http.StatusBadRequest, "The request body is not a valid JSON object.")
+		return
+	}
+
+	valid := requestBody.Username != ""
+	if requestBody.APIKey != "" {
+		valid = valid && requestBody.Password == "" && server.isValidAPIKey(requestBody.APIKey)
+	} else {
+		valid = valid && server.isValidPassword(requestBody.Password)
+	}
+	if !valid {
+		writeCp4dError(w, http.StatusUnauthorized, "Invalid user credentials")
+		return
+	}
+
+	issued, ok := server.issueToken(w, requestBody.Username, map[string]interface{}{
+		"username": requestBody.Username,
+		"role":     "User",
+	})
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"_messageCode_": "200",
+		"message":       "Success",
+		"token":         issued.token,
+	})
+}
+
+// isValidPassword returns true if the server accepts "password".
+func (server *Cp4dTokenServer) isValidPassword(password string) bool {
+	server.TokenServer.mutex.Lock()
+	defer server.TokenServer.mutex.Unlock()
+	return password != "" && (server.password == "" || password == server.password)
+}
@@ -0,0 +1,46 @@
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package coretest contains fake token servers that can be used to test SDKs built on
+the core package, without copying mock servers from one project to another.
+
+# Token Servers
+
+Each fake token server runs in-process (see httptest.Server) and implements the token operations
+invoked by one or more of the core package's authenticators:
+
+	IamTokenServer     IamAuthenticator, IamAssumeAuthenticator and ContainerAuthenticator
+	VpcTokenServer     VpcInstanceAuthenticator
+	Cp4dTokenServer    CloudPakForDataAuthenticator
+	MCSPTokenServer    MCSPAuthenticator
+	MCSPV2TokenServer  MCSPV2Authenticator
+
+The servers issue signed JWTs (see NewJWT), whose expiration can be controlled with
+TokenServer.SetExpiresIn. Failures and slow responses can be simulated with
+TokenServer.FailNext and TokenServer.SetDelay, and the requests received by a server
+can be inspected with TokenServer.Requests.
+
+For example:
+
+	server := coretest.NewIamTokenServer()
+	defer server.Close()
+	server.SetAPIKey("my-apikey")
+
+	authenticator, err := core.NewIamAuthenticatorBuilder().
+		SetApiKey("my-apikey").
+		SetURL(server.URL).
+		Build()
+*/
+package coretest
@@ -0,0 +1,170 @@
+package coretest
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// The grant types supported by IamTokenServer.
+const (
+	IamGrantTypeApiKey       = "urn:ibm:params:oauth:grant-type:apikey"   // #nosec G101
+	IamGrantTypeRefreshToken = "refresh_token"                            // #nosec G101
+	IamGrantTypeCRToken      = "urn:ibm:params:oauth:grant-type:cr-token" // #nosec G101
+	IamGrantTypeAssume       = "urn:ibm:params:oauth:grant-type:assume"
+)
+
+const iamTokenServerPath = "/identity/token"
+
+// IamTokenServer is a fake IAM token server, which implements the "get token" operation
+// (POST /identity/token) for the "apikey", "refresh_token", "cr-token" and "assume" grant types.
+//
+// For the "refresh_token" grant type, the refresh token must have been issued by the server
+// (see IamTokenServer.IssueRefreshToken).
+// For the "assume" grant type, the access token must have been issued by the server.
+type IamTokenServer struct {
+	*TokenServer
+
+	mutex         sync.Mutex
+	refreshTokens map[string]bool
+}
+
+// NewIamTokenServer returns a new, started IamTokenServer, whose URL is suitable
+// for the URL property of the IAM authenticators.
+func NewIamTokenServer() *IamTokenServer {
+	server := &IamTokenServer{refreshTokens: map[string]bool{}}
+	server.TokenServer = newTokenServer(server.handle, writeIamError)
+	return server
+}
+
+// writeIamError writes an error response in the format of the IAM token server.
+func writeIamError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"errorCode":    fmt.Sprintf("BXNIM%04dE", statusCode),
+		"errorMessage": message,
+		"context": map[string]string{
+			"requestId": "coretest",
+		},
+	})
+}
+
+func (server *IamTokenServer) handle(w http.ResponseWriter, req *http.Request, body []byte) {
+	if req.URL.Path != iamTokenServerPath || req.Method != http.MethodPost {
+		writeIamError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		writeIamError(w, http.StatusBadRequest, "The request body is not a valid form.")
+		return
+	}
+
+	grantType := form.Get("grant_type")
+	subject := ""
+	withRefreshToken := true
+	switch grantType {
+	case IamGrantTypeApiKey:
+		if !server.isValidAPIKey(form.Get("apikey")) {
+			writeIamError(w, http.StatusBadRequest, "Provided API key could not be found.")
+			return
+		}
+		subject = "iam-ServiceId-coretest"
+
+	case IamGrantTypeRefreshToken:
+		if !server.isRefreshToken(form.Get("refresh_token")) {
+			writeIamError(w, http.StatusBadRequest, "Provided refresh token is invalid.")
+			return
+		}
+		subject = "iam-ServiceId-coretest"
+
+	case IamGrantTypeCRToken:
+		if form.Get("cr_token") == "" {
+			writeIamError(w, http.StatusBadRequest, "Provided compute resource token is invalid.")
+			return
+		}
+		subject = iamProfileSubject(form)
+		if subject == "" {
+			writeIamError(w, http.StatusBadRequest, "A trusted profile must be specified.")
+			return
+		}
+
+	case IamGrantTypeAssume:
+		if !server.isIssuedToken(form.Get("access_token")) {
+			writeIamError(w, http.StatusBadRequest, "Provided access token is invalid.")
+			return
+		}
+		subject = iamProfileSubject(form)
+		if subject == "" || (form.Get("profile_name") != "" && form.Get("account") == "") {
+			writeIamError(w, http.StatusBadRequest, "A trusted profile must be specified.")
+			return
+		}
+		withRefreshToken = false
+
+	default:
+		writeIamError(w, http.StatusBadRequest, fmt.Sprintf("Unsupported grant type: '%s'.", grantType))
+		return
+	}
+
+	issued, ok := server.issueToken(w, subject, map[string]interface{}{
+		"iam_id":     subject,
+		"grant_type": grantType,
+		"scope":      "ibm openid",
+	})
+	if !ok {
+		return
+	}
+	result := map[string]interface{}{
+		"access_token": issued.token,
+		"token_type":   "Bearer",
+		"expires_in":   issued.expiresIn(),
+		"expiration":   issued.expiresAt.Unix(),
+		"scope":        "ibm openid",
+	}
+	if withRefreshToken {
+		result["refresh_token"] = server.IssueRefreshToken()
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// IssueRefreshToken returns a new refresh token, which may be exchanged for access tokens
+// (e.g. by an IamAuthenticator configured with the refresh token).
+func (server *IamTokenServer) IssueRefreshToken() string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	refreshToken := fmt.Sprintf("coretest-refresh-token-%d", len(server.refreshTokens)+1)
+	server.refreshTokens[refreshToken] = true
+	return refreshToken
+}
+
+// isRefreshToken returns true if "refreshToken" was issued by the server.
+func (server *IamTokenServer) isRefreshToken(refreshToken string) bool {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.refreshTokens[refreshToken]
+}
+
+// iamProfileSubject returns the subject of a token for the trusted profile specified in "form",
+// or "" if no trusted profile is specified.
+func iamProfileSubject(form url.Values) string {
+	for _, name := range []string{"profile_crn", "profile_id", "profile_name"} {
+		if value := form.Get(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package coretest
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// JWTSigningKey is the key with which the JWTs returned by NewJWT are signed (with HS256).
+// The SDK doesn't verify the signature of access tokens, but a test may do so.
+var JWTSigningKey = []byte("coretest-signing-key")
+
+// NewJWT returns a new JWT with the specified claims, signed with JWTSigningKey,
+// or an error if the claims can't be serialized as JSON.
+func NewJWT(claims map[string]interface{}) (token string, err error) {
+	header, _ := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT", "kid": "coretest"})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return
+	}
+	encode := base64.RawURLEncoding.EncodeToString
+	unsigned := encode(header) + "." + encode(payload)
+	mac := hmac.New(sha256.New, JWTSigningKey)
+	mac.Write([]byte(unsigned))
+	token = unsigned + "." + encode(mac.Sum(nil))
+	return
+}
+
+// ParseJWT returns the claims of a JWT returned by NewJWT, or an error
+// if the JWT is malformed or its signature is invalid.
+func ParseJWT(token string) (claims map[string]interface{}, err error) {
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		err = errors.New("the JWT must have 3 segments")
+		return
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	if err != nil {
+		return
+	}
+	mac := hmac.New(sha256.New, JWTSigningKey)
+	mac.Write([]byte(segments[0] + "." + segments[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		err = errors.New("the signature of the JWT is invalid")
+		return
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(segments[1])
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(payload, &claims)
+	return
+}
@@ -0,0 +1,135 @@
+package coretest
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+)
+
+const mcspTokenServerPath = "/siusermgr/api/1.0/apikeys/token"
+
+// mcspv2TokenServerPath matches "/api/2.0/{scopeCollectionType}/{scopeId}/apikeys/token".
+var mcspv2TokenServerPath = regexp.MustCompile(`^/api/2\.0/([^/]+)/([^/]+)/apikeys/token$`)
+
+// MCSPTokenServer is a fake MCSP token server, which implements the MCSP v1
+// token-exchange operation (POST /siusermgr/api/1.0/apikeys/token).
+type MCSPTokenServer struct {
+	*TokenServer
+}
+
+// NewMCSPTokenServer returns a new, started MCSPTokenServer, whose URL is suitable
+// for the URL property of an MCSPAuthenticator.
+func NewMCSPTokenServer() *MCSPTokenServer {
+	server := &MCSPTokenServer{}
+	server.TokenServer = newTokenServer(server.handle, writeMCSPError)
+	return server
+}
+
+// MCSPV2TokenServer is a fake MCSP token server, which implements the MCSP v2
+// token-exchange operation (POST /api/2.0/{scopeCollectionType}/{scopeId}/apikeys/token).
+// The scope and the "callerExtClaim" property of the request are included in the claims of the tokens.
+type MCSPV2TokenServer struct {
+	*TokenServer
+}
+
+// NewMCSPV2TokenServer returns a new, started MCSPV2TokenServer, whose URL is suitable
+// for the URL property of an MCSPV2Authenticator.
+func NewMCSPV2TokenServer() *MCSPV2TokenServer {
+	server := &MCSPV2TokenServer{}
+	server.TokenServer = newTokenServer(server.handle, writeMCSPError)
+	return server
+}
+
+// writeMCSPError writes an error response in the format of the MCSP token server.
+func writeMCSPError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"errors": []map[string]string{
+			{"code": http.StatusText(statusCode), "message": message},
+		},
+		"status_code": statusCode,
+		"trace":       "coretest",
+	})
+}
+
+// mcspRequestBody is the request body of both versions of the token-exchange operation.
+type mcspRequestBody struct {
+	ApiKey         string                 `json:"apikey"`
+	CallerExtClaim map[string]interface{} `json:"callerExtClaim,omitempty"`
+}
+
+// parseMCSPRequest returns the body of a token-exchange request, or writes an error response
+// and returns false if the request is invalid.
+func parseMCSPRequest(server *TokenServer, w http.ResponseWriter, body []byte) (*mcspRequestBody, bool) {
+	requestBody := &mcspRequestBody{}
+	if json.Unmarshal(body, requestBody) != nil {
+		writeMCSPError(w, http.StatusBadRequest, "The request body is not a valid JSON object.")
+		return nil, false
+	}
+	if !server.isValidAPIKey(requestBody.ApiKey) {
+		writeMCSPError(w, http.StatusUnauthorized, "The apikey is invalid.")
+		return nil, false
+	}
+	return requestBody, true
+}
+
+func (server *MCSPTokenServer) handle(w http.ResponseWriter, req *http.Request, body []byte) {
+	if req.URL.Path != mcspTokenServerPath || req.Method != http.MethodPost {
+		writeMCSPError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if _, ok := parseMCSPRequest(server.TokenServer, w, body); !ok {
+		return
+	}
+	issued, ok := server.issueToken(w, "coretest-user", nil)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"token":      issued.token,
+		"token_type": "jwt",
+		"expires_in": issued.expiresIn(),
+	})
+}
+
+func (server *MCSPV2TokenServer) handle(w http.ResponseWriter, req *http.Request, body []byte) {
+	scope := mcspv2TokenServerPath.FindStringSubmatch(req.URL.Path)
+	if scope == nil || req.Method != http.MethodPost {
+		writeMCSPError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	requestBody, ok := parseMCSPRequest(server.TokenServer, w, body)
+	if !ok {
+		return
+	}
+	claims := map[string]interface{}{
+		"scopeCollectionType": scope[1],
+		"scopeId":             scope[2],
+	}
+	if requestBody.CallerExtClaim != nil {
+		claims["callerExtClaim"] = requestBody.CallerExtClaim
+	}
+	issued, ok := server.issueToken(w, "coretest-user", claims)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"token":      issued.token,
+		"token_type": "Bearer",
+		"expires_in": issued.expiresIn(),
+		"expiration": issued.expiresAt.Unix(),
+	})
+}
@@ -0,0 +1,234 @@
+package coretest
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// DefaultExpiresIn is the lifetime of the tokens issued by a token server, unless changed with TokenServer.SetExpiresIn.
+const DefaultExpiresIn = time.Hour
+
+// TokenServer contains the behavior common to all of the fake token servers in this package.
+// The embedded httptest.Server provides the server's URL and must be closed when it's no longer needed.
+type TokenServer struct {
+	*httptest.Server
+
+	mutex     sync.Mutex
+	expiresIn time.Duration
+	delay     time.Duration
+	apikey    string
+	failures  []tokenServerFailure
+	requests  []Request
+	tokens    []string
+
+	// writeError writes an error response in the format of the specific token server.
+	writeError func(w http.ResponseWriter, statusCode int, message string)
+}
+
+// Request is a request received by a token server.
+type Request struct {
+	Method string
+	Path   string
+	Query  map[string][]string
+	Header http.Header
+	Body   []byte
+}
+
+type tokenServerFailure struct {
+	statusCode int
+	body       string
+}
+
+// tokenServerHandler handles a request (whose body is "body") received by a token server.
+type tokenServerHandler func(w http.ResponseWriter, req *http.Request, body []byte)
+
+// newTokenServer returns a new, started TokenServer that handles requests with "handler".
+func newTokenServer(handler tokenServerHandler, writeError func(http.ResponseWriter, int, string)) *TokenServer {
+	server := &TokenServer{
+		expiresIn:  DefaultExpiresIn,
+		writeError: writeError,
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		server.mutex.Lock()
+		server.requests = append(server.requests, Request{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  req.URL.Query(),
+			Header: req.Header.Clone(),
+			Body:   body,
+		})
+		delay := server.delay
+		var failure *tokenServerFailure
+		if len(server.failures) > 0 {
+			failure = &server.failures[0]
+			server.failures = server.failures[1:]
+		}
+		server.mutex.Unlock()
+
+		if delay > 0 {
+			select {
+			case <-time.After(delay):
+			case <-req.Context().Done():
+				return
+			}
+		}
+		if failure != nil {
+			if failure.body == "" {
+				server.writeError(w, failure.statusCode, http.StatusText(failure.statusCode))
+			} else {
+				w.WriteHeader(failure.statusCode)
+				fmt.Fprint(w, failure.body)
+			}
+			return
+		}
+		handler(w, req, body)
+	}))
+	return server
+}
+
+// SetExpiresIn sets the lifetime of the tokens issued by the server from now on.
+// A negative lifetime may be used to issue tokens that have already expired.
+func (server *TokenServer) SetExpiresIn(expiresIn time.Duration) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.expiresIn = expiresIn
+}
+
+// SetDelay sets the time that the server waits before it responds to each request
+// (e.g. to exercise the timeouts of an authenticator).
+func (server *TokenServer) SetDelay(delay time.Duration) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.delay = delay
+}
+
+// SetAPIKey sets the only apikey that the server accepts. By default, any apikey is accepted.
+func (server *TokenServer) SetAPIKey(apikey string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.apikey = apikey
+}
+
+// FailNext causes the next "count" requests received by the server to fail with "statusCode".
+// The body of the error responses is "body", or else an error in the format of the token server.
+func (server *TokenServer) FailNext(count int, statusCode int, body string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	for i := 0; i < count; i++ {
+		server.failures = append(server.failures, tokenServerFailure{statusCode: statusCode, body: body})
+	}
+}
+
+// Requests returns the requests received by the server so far.
+func (server *TokenServer) Requests() []Request {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return append([]Request{}, server.requests...)
+}
+
+// Tokens returns the access tokens issued by the server so far, in order.
+func (server *TokenServer) Tokens() []string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return append([]string{}, server.tokens...)
+}
+
+// LastToken returns the last access token issued by the server, or "" if none was issued.
+func (server *TokenServer) LastToken() string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if len(server.tokens) == 0 {
+		return ""
+	}
+	return server.tokens[len(server.tokens)-1]
+}
+
+// issuedToken describes a token issued by a token server.
+type issuedToken struct {
+	token     string
+	issuedAt  time.Time
+	expiresAt time.Time
+}
+
+// expiresIn returns the lifetime of the token, in seconds.
+func (token *issuedToken) expiresIn() int64 {
+	return int64(token.expiresAt.Sub(token.issuedAt) / time.Second)
+}
+
+// issueToken returns a new JWT with the specified claims, in addition to the standard ones.
+// If the JWT can't be created, an error response is written and false is returned.
+func (server *TokenServer) issueToken(w http.ResponseWriter, subject string, claims map[string]interface{}) (*issuedToken, bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	issuedAt := time.Now().Truncate(time.Second)
+	expiresAt := issuedAt.Add(server.expiresIn)
+	allClaims := map[string]interface{}{
+		"iss": server.URL,
+		"sub": subject,
+		"iat": issuedAt.Unix(),
+		"exp": expiresAt.Unix(),
+		"jti": fmt.Sprintf("coretest-%d", len(server.tokens)+1),
+	}
+	for name, value := range claims {
+		allClaims[name] = value
+	}
+	token, err := NewJWT(allClaims)
+	if err != nil {
+		server.writeError(w, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	server.tokens = append(server.tokens, token)
+	return &issuedToken{token: token, issuedAt: issuedAt, expiresAt: expiresAt}, true
+}
+
+// isValidAPIKey returns true if the server accepts "apikey".
+func (server *TokenServer) isValidAPIKey(apikey string) bool {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return apikey != "" && (server.apikey == "" || apikey == server.apikey)
+}
+
+// isIssuedToken returns true if "token" was issued by the server.
+func (server *TokenServer) isIssuedToken(token string) bool {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	for _, issued := range server.tokens {
+		if issued == token {
+			return true
+		}
+	}
+	return false
+}
+
+// writeJSON writes a response with "statusCode" and "result" serialized as JSON.
+func writeJSON(w http.ResponseWriter, statusCode int, result interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,325 @@
+//go:build all || fast || auth
+
+package coretest
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/stretchr/testify/assert"
+)
+
+// assertTokenExpiresIn asserts that "token" was issued by NewJWT and expires in "expiresIn".
+func assertTokenExpiresIn(t *testing.T, token string, expiresIn time.Duration) {
+	claims, err := ParseJWT(token)
+	assert.Nil(t, err)
+	assert.Equal(t, expiresIn.Seconds(), claims["exp"].(float64)-claims["iat"].(float64))
+}
+
+func TestIamTokenServer(t *testing.T) {
+	server := NewIamTokenServer()
+	defer server.Close()
+	server.SetAPIKey("my-apikey")
+
+	authenticator, err := core.NewIamAuthenticatorBuilder().
+		SetApiKey("my-apikey").
+		SetURL(server.URL).
+		Build()
+	assert.Nil(t, err)
+	token, err := authenticator.GetToken()
+	assert.Nil(t, err)
+	assert.Equal(t, server.LastToken(), token)
+	assertTokenExpiresIn(t, token, DefaultExpiresIn)
+
+	requests := server.Requests()
+	assert.Len(t, requests, 1)
+	assert.Equal(t, http.MethodPost, requests[0].Method)
+	assert.Equal(t, "/identity/token", requests[0].Path)
+	assert.Contains(t, string(requests[0].Body), "apikey=my-apikey")
+
+	// Only the configured apikey is accepted.
+	authenticator, err = core.NewIamAuthenticatorBuilder().
+		SetApiKey("another-apikey").
+		SetURL(server.URL).
+		Build()
+	assert.Nil(t, err)
+	_, err = authenticator.GetToken()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Provided API key could not be found.")
+
+	// Refresh tokens issued by the server may be exchanged for access tokens.
+	authenticator, err = core.NewIamAuthenticatorBuilder().
+		SetRefreshToken(server.IssueRefreshToken()).
+		SetClientIDSecret("bx", "bx").
+		SetURL(server.URL).
+		Build()
+	assert.Nil(t, err)
+	token, err = authenticator.GetToken()
+	assert.Nil(t, err)
+	assert.Equal(t, server.LastToken(), token)
+
+	authenticator, err = core.NewIamAuthenticatorBuilder().
+		SetRefreshToken("unknown").
+		SetClientIDSecret("bx", "bx").
+		SetURL(server.URL).
+		Build()
+	assert.Nil(t, err)
+	_, err = authenticator.GetToken()
+	assert.NotNil(t, err)
+}
+
+func TestIamTokenServerAssume(t *testing.T) {
+	server := NewIamTokenServer()
+	defer server.Close()
+
+	authenticator, err := core.NewIamAssumeAuthenticatorBuilder().
+		SetApiKey("my-apikey").
+		SetIAMProfileName("my-profile").
+		SetIAMAccountID("my-account").
+		SetURL(server.URL).
+		Build()
+	assert.Nil(t, err)
+	token, err := authenticator.GetToken()
+	assert.Nil(t, err)
+
+	// The user's token was exchanged for the profile's token.
+	tokens := server.Tokens()
+	assert.Len(t, tokens, 2)
+	assert.Equal(t, tokens[1], token)
+	claims, err := ParseJWT(token)
+	assert.Nil(t, err)
+	assert.Equal(t, "my-profile", claims["sub"])
+	assert.Equal(t, IamGrantTypeAssume, claims["grant_type"])
+}
+
+func TestIamTokenServerCRToken(t *testing.T) {
+	server := NewIamTokenServer()
+	defer server.Close()
+
+	crTokenFilename := filepath.Join(t.TempDir(), "cr-token")
+	assert.Nil(t, os.WriteFile(crTokenFilename, []byte("cr-token"), 0600))
+	authenticator, err := core.NewContainerAuthenticatorBuilder().
+		SetCRTokenFilename(crTokenFilename).
+		SetIAMProfileID("my-profile-id").
+		SetURL(server.URL).
+		Build()
+	assert.Nil(t, err)
+	token, err := authenticator.GetToken()
+	assert.Nil(t, err)
+	claims, err := ParseJWT(token)
+	assert.Nil(t, err)
+	assert.Equal(t, "my-profile-id", claims["sub"])
+	assert.Equal(t, IamGrantTypeCRToken, claims["grant_type"])
+}
+
+func TestTokenServerExpiration(t *testing.T) {
+	server := NewIamTokenServer()
+	defer server.Close()
+	server.SetExpiresIn(10 * time.Minute)
+
+	authenticator, err := core.NewIamAuthenticatorBuilder().
+		SetApiKey("my-apikey").
+		SetURL(server.URL).
+		Build()
+	assert.Nil(t, err)
+	token, err := authenticator.GetToken()
+	assert.Nil(t, err)
+	assertTokenExpiresIn(t, token, 10*time.Minute)
+
+	// An expired token is replaced as soon as it's needed.
+	server.SetExpiresIn(-time.Minute)
+	authenticator, err = core.NewIamAuthenticatorBuilder().
+		SetApiKey("my-apikey").
+		SetURL(server.URL).
+		Build()
+	assert.Nil(t, err)
+	token1, err := authenticator.GetToken()
+	assert.Nil(t, err)
+	token2, err := authenticator.GetToken()
+	assert.Nil(t, err)
+	assert.NotEqual(t, token1, token2)
+	assert.Len(t, server.Tokens(), 3)
+}
+
+func TestTokenServerFailures(t *testing.T) {
+	server := NewMCSPTokenServer()
+	defer server.Close()
+	server.FailNext(1, http.StatusInternalServerError, "")
+	server.FailNext(1, http.StatusOK, "not json")
+
+	authenticator, err := core.NewMCSPAuthenticatorBuilder().
+		SetApiKey("my-apikey").
+		SetURL(server.URL).
+		Build()
+	assert.Nil(t, err)
+	_, err = authenticator.GetToken()
+	assert.NotNil(t, err)
+	authErr, ok := err.(*core.AuthenticationError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusInternalServerError, authErr.Response.GetStatusCode())
+
+	_, err = authenticator.GetToken()
+	assert.NotNil(t, err)
+
+	// The failures have been used up.
+	token, err := authenticator.GetToken()
+	assert.Nil(t, err)
+	assert.Equal(t, server.LastToken(), token)
+	assert.Len(t, server.Requests(), 3)
+}
+
+func TestTokenServerDelay(t *testing.T) {
+	server := NewCp4dTokenServer()
+	defer server.Close()
+	server.SetDelay(time.Second)
+
+	authenticator, err := core.NewCloudPakForDataAuthenticatorUsingPassword(server.URL, "my-user", "my-password", false, nil)
+	assert.Nil(t, err)
+	authenticator.Client = &http.Client{Timeout: 50 * time.Millisecond}
+	_, err = authenticator.GetToken()
+	assert.NotNil(t, err)
+
+	server.SetDelay(0)
+	_, err = authenticator.GetToken()
+	assert.Nil(t, err)
+}
+
+func TestCp4dTokenServer(t *testing.T) {
+	server := NewCp4dTokenServer()
+	defer server.Close()
+	server.SetPassword("my-password")
+	server.SetAPIKey("my-apikey")
+
+	authenticator, err := core.NewCloudPakForDataAuthenticatorUsingPassword(server.URL+"/icp4d-api", "my-user", "my-password", false, nil)
+	assert.Nil(t, err)
+	token, err := authenticator.GetToken()
+	assert.Nil(t, err)
+	claims, err := ParseJWT(token)
+	assert.Nil(t, err)
+	assert.Equal(t, "my-user", claims["username"])
+	assert.Equal(t, "/icp4d-api/v1/authorize", server.Requests()[0].Path)
+
+	authenticator, err = core.NewCloudPakForDataAuthenticatorUsingAPIKey(server.URL, "my-user", "my-apikey", false, nil)
+	assert.Nil(t, err)
+	_, err = authenticator.GetToken()
+	assert.Nil(t, err)
+
+	authenticator, err = core.NewCloudPakForDataAuthenticatorUsingPassword(server.URL, "my-user", "another-password", false, nil)
+	assert.Nil(t, err)
+	_, err = authenticator.GetToken()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Invalid user credentials")
+}
+
+func TestVpcTokenServer(t *testing.T) {
+	for _, version := range []string{"", "2025-08-26"} {
+		server := NewVpcTokenServer()
+		authenticator, err := core.NewVpcInstanceAuthenticatorBuilder().
+			SetIAMProfileCRN("crn:my-profile").
+			SetServiceVersion(version).
+			SetURL(server.URL).
+			Build()
+		assert.Nil(t, err)
+		token, err := authenticator.GetToken()
+		assert.Nil(t, err)
+		assert.Equal(t, server.LastToken(), token)
+		claims, err := ParseJWT(token)
+		assert.Nil(t, err)
+		assert.Equal(t, "crn:my-profile", claims["sub"])
+
+		requests := server.Requests()
+		assert.Len(t, requests, 2)
+		assert.Equal(t, http.MethodPut, requests[0].Method)
+		assert.Equal(t, http.MethodPost, requests[1].Method)
+		assert.Equal(t, "Bearer "+server.Tokens()[0], requests[1].Header.Get("Authorization"))
+		server.Close()
+	}
+}
+
+func TestMCSPTokenServer(t *testing.T) {
+	server := NewMCSPTokenServer()
+	defer server.Close()
+	server.SetAPIKey("my-apikey")
+
+	authenticator, err := core.NewMCSPAuthenticatorBuilder().
+		SetApiKey("my-apikey").
+		SetURL(server.URL).
+		Build()
+	assert.Nil(t, err)
+	token, err := authenticator.GetToken()
+	assert.Nil(t, err)
+	assert.Equal(t, server.LastToken(), token)
+	claims, err := ParseJWT(token)
+	assert.Nil(t, err)
+	assert.Equal(t, "coretest-user", claims["sub"])
+
+	requests := server.Requests()
+	assert.Len(t, requests, 1)
+	assert.Equal(t, http.MethodPost, requests[0].Method)
+	assert.Equal(t, "/siusermgr/api/1.0/apikeys/token", requests[0].Path)
+
+	authenticator.ApiKey = "another-apikey"
+	_, err = authenticator.RequestToken()
+	assert.NotNil(t, err)
+}
+
+func TestMCSPV2TokenServer(t *testing.T) {
+	server := NewMCSPV2TokenServer()
+	defer server.Close()
+	server.SetAPIKey("my-apikey")
+
+	authenticator, err := core.NewMCSPV2AuthenticatorBuilder().
+		SetApiKey("my-apikey").
+		SetURL(server.URL).
+		SetScopeCollectionType("accounts").
+		SetScopeID("global_account").
+		SetCallerExtClaim(map[string]string{"productID": "my-product"}).
+		Build()
+	assert.Nil(t, err)
+	token, err := authenticator.GetToken()
+	assert.Nil(t, err)
+	claims, err := ParseJWT(token)
+	assert.Nil(t, err)
+	assert.Equal(t, "accounts", claims["scopeCollectionType"])
+	assert.Equal(t, "global_account", claims["scopeId"])
+	assert.Equal(t, map[string]interface{}{"productID": "my-product"}, claims["callerExtClaim"])
+
+	authenticator.ApiKey = "another-apikey"
+	_, err = authenticator.RequestToken()
+	assert.NotNil(t, err)
+}
+
+func TestParseJWT(t *testing.T) {
+	token, err := NewJWT(map[string]interface{}{"sub": "me"})
+	assert.Nil(t, err)
+	claims, err := ParseJWT(token)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"sub": "me"}, claims)
+
+	_, err = ParseJWT(token + "x")
+	assert.NotNil(t, err)
+	_, err = ParseJWT("a.b")
+	assert.NotNil(t, err)
+
+	// The claims must be serializable as JSON.
+	_, err = NewJWT(map[string]interface{}{"sub": make(chan int)})
+	assert.NotNil(t, err)
+}
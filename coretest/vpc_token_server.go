@@ -0,0 +1,114 @@
+package coretest
+
+// (C) Copyright IBM Corp. 2026.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	vpcPathCreateAccessToken   = "/instance_identity/v1/token"
+	vpcPathCreateIamToken      = "/instance_identity/v1/iam_token"
+	vpcPathCreateAccessTokenV2 = "/identity/v1/token"
+	vpcPathCreateIamTokenV2    = "/identity/v1/iam_tokens"
+	vpcMetadataFlavor          = "ibm"
+)
+
+// VpcTokenServer is a fake VPC Instance Metadata Service, which implements the "create_access_token"
+// operation (PUT /instance_identity/v1/token or /identity/v1/token) and the "create_iam_token" operation
+// (POST /instance_identity/v1/iam_token or /identity/v1/iam_tokens).
+//
+// The "create_iam_token" operation requires an instance identity token issued by the server.
+// Both instance identity tokens and IAM access tokens are included in TokenServer.Tokens.
+type VpcTokenServer struct {
+	*TokenServer
+}
+
+// NewVpcTokenServer returns a new, started VpcTokenServer, whose URL is suitable
+// for the URL property of a VpcInstanceAuthenticator.
+func NewVpcTokenServer() *VpcTokenServer {
+	server := &VpcTokenServer{}
+	server.TokenServer = newTokenServer(server.handle, writeVpcError)
+	return server
+}
+
+// writeVpcError writes an error response in the format of the VPC Instance Metadata Service.
+func writeVpcError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]interface{}{
+		"errors": []map[string]string{
+			{"code": strings.ToLower(strings.ReplaceAll(http.StatusText(statusCode), " ", "_")), "message": message},
+		},
+		"trace": "coretest",
+	})
+}
+
+func (server *VpcTokenServer) handle(w http.ResponseWriter, req *http.Request, body []byte) {
+	if req.Header.Get("Metadata-Flavor") != vpcMetadataFlavor {
+		writeVpcError(w, http.StatusBadRequest, "The 'Metadata-Flavor' header must be 'ibm'.")
+		return
+	}
+	if req.URL.Query().Get("version") == "" {
+		writeVpcError(w, http.StatusBadRequest, "The 'version' query parameter is required.")
+		return
+	}
+
+	// Both operations accept a JSON object.
+	var requestBody map[string]interface{}
+	if len(body) > 0 && json.Unmarshal(body, &requestBody) != nil {
+		writeVpcError(w, http.StatusBadRequest, "The request body is not a valid JSON object.")
+		return
+	}
+
+	switch {
+	case req.Method == http.MethodPut && (req.URL.Path == vpcPathCreateAccessToken || req.URL.Path == vpcPathCreateAccessTokenV2):
+		if issued, ok := server.issueToken(w, "crn:v1:coretest:public:is:us-south-1:a/coretest::instance:coretest", nil); ok {
+			server.writeToken(w, issued)
+		}
+
+	case req.Method == http.MethodPost && (req.URL.Path == vpcPathCreateIamToken || req.URL.Path == vpcPathCreateIamTokenV2):
+		identityToken := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !server.isIssuedToken(identityToken) {
+			writeVpcError(w, http.StatusUnauthorized, "The instance identity token is invalid.")
+			return
+		}
+		subject := "iam-ServiceId-coretest"
+		if profile, ok := requestBody["trusted_profile"].(map[string]interface{}); ok {
+			for _, name := range []string{"crn", "id"} {
+				if value, ok := profile[name].(string); ok && value != "" {
+					subject = value
+				}
+			}
+		}
+		if issued, ok := server.issueToken(w, subject, map[string]interface{}{"iam_id": subject}); ok {
+			server.writeToken(w, issued)
+		}
+
+	default:
+		writeVpcError(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+// writeToken writes the response of either operation.
+func (server *VpcTokenServer) writeToken(w http.ResponseWriter, issued *issuedToken) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": issued.token,
+		"created_at":   issued.issuedAt.UTC().Format(time.RFC3339),
+		"expires_at":   issued.expiresAt.UTC().Format(time.RFC3339),
+		"expires_in":   issued.expiresIn(),
+	})
+}
@@ -7,6 +7,7 @@ require (
 	github.com/go-playground/validator/v10 v10.28.0
 	github.com/hashicorp/go-cleanhttp v0.5.2
 	github.com/hashicorp/go-retryablehttp v0.7.8
+	github.com/klauspost/compress v1.18.0
 	github.com/onsi/ginkgo v1.16.5
 	github.com/onsi/gomega v1.37.0
 	github.com/stretchr/testify v1.10.0